@@ -0,0 +1,85 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	bodyEncodingJSON    = "json"
+	bodyEncodingCBOR    = "cbor"
+	bodyEncodingMsgpack = "msgpack"
+)
+
+// cborEncMode encodes maps with sorted keys, so that the same payload always
+// results in the same bytes, like the JSON encoding.
+var cborEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+// bodyEncodingContentType returns the Content-Type of structured payloads
+// encoded with the configured body encoding.
+func bodyEncodingContentType(encoding string) string {
+	switch encoding {
+	case bodyEncodingCBOR:
+		return "application/cbor"
+	case bodyEncodingMsgpack:
+		return "application/x-msgpack"
+	default:
+		return "application/json"
+	}
+}
+
+// encodePayload encodes the record payload using the configured body
+// encoding. Only structured payloads are encoded, raw payloads are sent as is.
+func (d *Destination) encodePayload(payload opencdc.Data) ([]byte, error) {
+	sd, ok := payload.(opencdc.StructuredData)
+	if !ok {
+		return payload.Bytes(), nil
+	}
+
+	var b []byte
+	var err error
+	switch d.config.BodyEncoding {
+	case bodyEncodingCBOR:
+		b, err = cborEncMode.Marshal(map[string]any(sd))
+	case bodyEncodingMsgpack:
+		b, err = encodeMsgpack(sd)
+	default:
+		return sd.Bytes(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encoding payload as %v: %w", d.config.BodyEncoding, err)
+	}
+	return b, nil
+}
+
+// encodeMsgpack encodes structured data as MessagePack, with sorted map keys
+// and using the JSON field names of structs.
+func encodeMsgpack(sd opencdc.StructuredData) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetSortMapKeys(true)
+	enc.SetCustomStructTag("json")
+	err := enc.Encode(map[string]any(sd))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
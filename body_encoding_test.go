@@ -0,0 +1,95 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/matryer/is"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestDestination_BodyEncoding(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		encoding    string
+		contentType string
+		decode      func([]byte, any) error
+	}{
+		{encoding: "cbor", contentType: "application/cbor", decode: cbor.Unmarshal},
+		{encoding: "msgpack", contentType: "application/x-msgpack", decode: msgpack.Unmarshal},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.encoding, func(t *testing.T) {
+			is := is.New(t)
+
+			var bodies [][]byte
+			var contentTypes []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					return
+				}
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, b)
+				contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+			}))
+			t.Cleanup(server.Close)
+
+			dest := NewDestination()
+			err := dest.Configure(ctx, map[string]string{
+				"url":          server.URL,
+				"bodyEncoding": tc.encoding,
+			})
+			is.NoErr(err)
+			err = dest.Open(ctx)
+			is.NoErr(err)
+
+			_, err = dest.Write(ctx, []opencdc.Record{
+				{Payload: opencdc.Change{After: opencdc.StructuredData{"id": "a", "tags": []any{"x"}}}},
+				{Payload: opencdc.Change{After: opencdc.RawData("raw")}},
+			})
+			is.NoErr(err)
+			is.Equal(len(bodies), 2)
+
+			var got map[string]any
+			err = tc.decode(bodies[0], &got)
+			is.NoErr(err)
+			is.Equal(got, map[string]any{"id": "a", "tags": []any{"x"}})
+			is.Equal(contentTypes[0], tc.contentType)
+
+			// raw payloads are sent as is
+			is.Equal(string(bodies[1]), "raw")
+			is.Equal(contentTypes[1], "text/plain; charset=utf-8")
+		})
+	}
+}
+
+func TestDestination_BodyEncodingInvalid(t *testing.T) {
+	is := is.New(t)
+
+	err := NewDestination().Configure(context.Background(), map[string]string{
+		"url":          "http://localhost",
+		"bodyEncoding": "cbor",
+		"contentType":  "form",
+	})
+	is.True(err != nil)
+}
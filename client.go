@@ -0,0 +1,245 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"golang.org/x/time/rate"
+)
+
+// newClient creates the HTTP client used for all the requests sent by the
+// connector, including the connection test.
+func (s *Config) newClient(ctx context.Context) (*http.Client, error) {
+	if s.TLSInsecureSkipVerify {
+		sdk.Logger(ctx).Warn().Msg("tls.insecureSkipVerify is enabled, the server's certificate won't be verified, do not use this in production")
+	}
+
+	var roundTripper http.RoundTripper
+	var err error
+	if s.HTTP2 {
+		roundTripper, err = s.newHTTP2Transport()
+	} else {
+		roundTripper, err = s.newTransport()
+	}
+	if err != nil {
+		return nil, err
+	}
+	// token requests of OAuth2 aren't logged, their bodies contain credentials
+	// and tokens
+	tokenTransport := roundTripper
+	if s.LogRequests || s.LogResponses {
+		roundTripper = &loggingRoundTripper{base: roundTripper, cfg: s}
+	}
+	if s.MetricsEnabled {
+		roundTripper = &metricsRoundTripper{base: roundTripper, metrics: getExpvarMetrics()}
+	}
+	client := &http.Client{
+		Transport:     roundTripper,
+		Timeout:       s.RequestTimeout,
+		CheckRedirect: s.checkRedirect,
+	}
+	if s.Cookies {
+		// without options, cookies are scoped to the exact host they came from
+		client.Jar, err = cookiejar.New(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating cookie jar: %w", err)
+		}
+	}
+
+	if s.OAuth2TokenURL != "" {
+		tokenClient := *client
+		tokenClient.Transport = tokenTransport
+		client, err = s.newOAuth2Client(ctx, client, &tokenClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.RateLimitPerSecond > 0 {
+		// token requests of OAuth2 aren't limited, they use the base client
+		client.Transport = &rateLimitRoundTripper{
+			base:    client.Transport,
+			limiter: rate.NewLimiter(rate.Limit(s.RateLimitPerSecond), s.RateLimitBurst),
+			perHost: s.RateLimitPerHost,
+		}
+	}
+
+	return client, nil
+}
+
+// newScriptClient returns the HTTP client used for the requests sent by
+// scripts. Scripts can send requests to any host, so the OAuth2 token isn't
+// added to them, otherwise the client is configured like the given client of
+// the connector.
+func (s *Config) newScriptClient(ctx context.Context, client *http.Client) (*http.Client, error) {
+	if s.OAuth2TokenURL == "" {
+		return client, nil
+	}
+	cfg := *s
+	cfg.OAuth2TokenURL = ""
+	return cfg.newClient(ctx)
+}
+
+// checkRedirect decides if a redirect response is followed, based on
+// followRedirects and maxRedirects.
+func (s *Config) checkRedirect(_ *http.Request, via []*http.Request) error {
+	if !s.FollowRedirects {
+		return http.ErrUseLastResponse
+	}
+	if len(via) > s.MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", s.MaxRedirects)
+	}
+	return nil
+}
+
+// closeIdleConnections closes the idle connections of the round tripper, if
+// it supports it.
+func closeIdleConnections(rt http.RoundTripper) {
+	type closeIdler interface {
+		CloseIdleConnections()
+	}
+	if c, ok := rt.(closeIdler); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// newTransport creates the transport used by the HTTP client, based on Go's
+// default transport with the configured connection pool settings.
+func (s *Config) newTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = s.TransportMaxIdleConns
+	transport.MaxIdleConnsPerHost = s.TransportMaxIdleConnsPerHost
+	transport.IdleConnTimeout = s.TransportIdleConnTimeout
+
+	tlsCfg, err := s.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	return transport, nil
+}
+
+// tlsConfig returns the TLS config for the configured TLS options, or nil if
+// the default config should be used.
+func (s *Config) tlsConfig() (*tls.Config, error) {
+	if s.TLSCACert == "" && s.TLSClientCert == "" && s.TLSClientKey == "" && !s.TLSInsecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		//nolint:gosec // opt-in, only meant for testing against self-signed certificates
+		InsecureSkipVerify: s.TLSInsecureSkipVerify,
+	}
+
+	if s.TLSCACert != "" {
+		caCert, err := readPEM(s.TLSCACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading tls.caCert: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("tls.caCert doesn't contain a valid PEM encoded certificate")
+		}
+	}
+
+	if s.TLSClientCert != "" || s.TLSClientKey != "" {
+		if s.TLSClientCert == "" || s.TLSClientKey == "" {
+			return nil, errors.New("both tls.clientCert and tls.clientKey need to be set for mutual TLS")
+		}
+		// also fails if the private key doesn't match the certificate
+		cert, err := tls.LoadX509KeyPair(s.TLSClientCert, s.TLSClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// readPEM returns the value itself if it's PEM encoded data, otherwise it
+// treats the value as a path and returns the file contents.
+func readPEM(val string) ([]byte, error) {
+	if strings.Contains(val, "-----BEGIN") {
+		return []byte(val), nil
+	}
+	return os.ReadFile(val)
+}
+
+// newOAuth2Client wraps the base client into a client that obtains an access
+// token using the OAuth2 client credentials or refresh token flow and adds it
+// to every request. The token is cached and refreshed shortly before it expires.
+// Token requests are sent using tokenClient.
+func (s *Config) newOAuth2Client(ctx context.Context, base, tokenClient *http.Client) (*http.Client, error) {
+	// Tokens are refreshed for as long as the connector is running, so the
+	// token source can't be bound to the lifetime of ctx (e.g. of Open).
+	tokenCtx := context.WithValue(context.WithoutCancel(ctx), oauth2.HTTPClient, tokenClient)
+
+	var tokenSource oauth2.TokenSource
+	if s.OAuth2RefreshToken != "" {
+		oauthCfg := oauth2.Config{
+			ClientID:     s.OAuth2ClientID,
+			ClientSecret: s.OAuth2ClientSecret,
+			Endpoint:     oauth2.Endpoint{TokenURL: s.OAuth2TokenURL},
+		}
+		// the token source keeps the refresh token returned with a new access
+		// token, in case the server rotates it
+		tokenSource = oauthCfg.TokenSource(tokenCtx, &oauth2.Token{RefreshToken: s.OAuth2RefreshToken})
+	} else {
+		oauthCfg := clientcredentials.Config{
+			ClientID:     s.OAuth2ClientID,
+			ClientSecret: s.OAuth2ClientSecret,
+			TokenURL:     s.OAuth2TokenURL,
+			Scopes:       s.OAuth2Scopes,
+		}
+		if len(s.Auth.OAuth2.ExtraParams) > 0 {
+			oauthCfg.EndpointParams = make(url.Values, len(s.Auth.OAuth2.ExtraParams))
+			for key, val := range s.Auth.OAuth2.ExtraParams {
+				oauthCfg.EndpointParams.Set(key, val)
+			}
+		}
+		tokenSource = oauthCfg.TokenSource(tokenCtx)
+	}
+
+	// fetch the first token right away, so that a misconfiguration is
+	// reported when the connector starts
+	_, err := tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting OAuth2 token from %q: %w", s.OAuth2TokenURL, err)
+	}
+
+	client := *base
+	client.Transport = &oauth2.Transport{
+		Source: tokenSource,
+		Base:   base.Transport,
+	}
+	return &client, nil
+}
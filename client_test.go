@@ -0,0 +1,589 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestSource_OAuth2(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	tokenRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+			id, secret, _ := r.BasicAuth()
+			if id != "client-id" || secret != "client-secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token-1","token_type":"bearer","expires_in":3600}`)
+		case "/resource":
+			if r.Header.Get("Authorization") != "Bearer token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "protected resource")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                      server.URL + "/resource",
+		"auth.oauth2.tokenURL":     server.URL + "/token",
+		"auth.oauth2.clientID":     "client-id",
+		"auth.oauth2.clientSecret": "client-secret",
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "protected resource")
+	// the token is reused until it expires
+	is.Equal(tokenRequests, 1)
+}
+
+func TestSource_OAuth2RefreshToken(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var refreshTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			id, secret, _ := r.BasicAuth()
+			if id != "client-id" || secret != "client-secret" || r.FormValue("grant_type") != "refresh_token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			refreshTokens = append(refreshTokens, r.FormValue("refresh_token"))
+			n := len(refreshTokens)
+			w.Header().Set("Content-Type", "application/json")
+			// the token expires right away, so it's refreshed before each request,
+			// and the server rotates the refresh token
+			fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":1,"refresh_token":"refresh-%d"}`, n, n+1)
+		case "/resource":
+			fmt.Fprint(w, r.Header.Get("Authorization"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                      server.URL + "/resource",
+		"auth.oauth2.tokenURL":     server.URL + "/token",
+		"auth.oauth2.clientID":     "client-id",
+		"auth.oauth2.clientSecret": "client-secret",
+		"auth.oauth2.refreshToken": "refresh-1",
+		"validateConnection":       "false",
+		"pollingPeriod":            "1ms",
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "Bearer token-2")
+	rec, err = src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "Bearer token-3")
+	is.Equal(refreshTokens, []string{"refresh-1", "refresh-2", "refresh-3"})
+}
+
+func TestSource_OAuth2ExtraParams(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var tokenForm url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			_ = r.ParseForm()
+			tokenForm = r.PostForm
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"token-1","token_type":"bearer","expires_in":3600}`)
+		case "/resource":
+			fmt.Fprint(w, "protected resource")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                              server.URL + "/resource",
+		"auth.oauth2.tokenURL":             server.URL + "/token",
+		"auth.oauth2.clientID":             "client-id",
+		"auth.oauth2.clientSecret":         "client-secret",
+		"auth.oauth2.scopes":               "read",
+		"auth.oauth2.extraParams.audience": "https://api.example.com",
+		"auth.oauth2.extraParams.resource": "urn:resource",
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+	is.Equal(tokenForm, url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {"read"},
+		"audience":   {"https://api.example.com"},
+		"resource":   {"urn:resource"},
+	})
+}
+
+func TestSource_OAuth2InvalidCredentials(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                      server.URL + "/resource",
+		"auth.oauth2.tokenURL":     server.URL + "/token",
+		"auth.oauth2.clientID":     "client-id",
+		"auth.oauth2.clientSecret": "wrong-secret",
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.True(err != nil)
+}
+
+func TestSource_APIKey(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "secret" && r.URL.Query().Get("key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "protected resource")
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name string
+		cfg  map[string]string
+	}{{
+		name: "header",
+		cfg:  map[string]string{"auth.apiKey.header": "X-API-Key"},
+	}, {
+		name: "query param",
+		cfg:  map[string]string{"auth.apiKey.queryParam": "key"},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tc.cfg["url"] = server.URL
+			tc.cfg["auth.apiKey.value"] = "secret"
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.NoErr(err)
+
+			rec, err := src.Read(ctx)
+			is.NoErr(err)
+			is.Equal(string(rec.Payload.After.Bytes()), "protected resource")
+		})
+	}
+}
+
+func TestConfig_InvalidAPIKey(t *testing.T) {
+	is := is.New(t)
+
+	cfg := Config{RetryBackoffFactor: 2, APIKeyValue: "secret"}
+	is.True(cfg.Validate() != nil) // location is missing
+
+	cfg.APIKeyHeader = "X-API-Key"
+	cfg.APIKeyQueryParam = "key"
+	is.True(cfg.Validate() != nil) // only one location can be used
+
+	cfg = Config{RetryBackoffFactor: 2, APIKeyHeader: "X-API-Key"}
+	is.True(cfg.Validate() != nil) // value is missing
+}
+
+func TestSource_RequestTimeout(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(unblock) })
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"requestTimeout": "100ms",
+	})
+	is.NoErr(err)
+
+	start := time.Now()
+	err = src.Open(ctx, opencdc.Position{})
+	is.True(err != nil)
+	is.True(time.Since(start) < 5*time.Second)
+}
+
+func TestSource_TLSCACert(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "secure resource")
+	}))
+	t.Cleanup(server.Close)
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// the server's certificate isn't trusted by default
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{"url": server.URL})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.True(err != nil)
+
+	src = NewSource()
+	err = src.Configure(ctx, map[string]string{
+		"url":        server.URL,
+		"tls.caCert": string(caCert),
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "secure resource")
+}
+
+func TestSource_TLSInsecureSkipVerify(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "self-signed resource")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                    server.URL,
+		"tls.insecureSkipVerify": "true",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "self-signed resource")
+}
+
+func TestConfig_InvalidTLSCACert(t *testing.T) {
+	is := is.New(t)
+
+	cfg := Config{RetryBackoffFactor: 2, TLSCACert: "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----"}
+	is.True(cfg.Validate() != nil)
+
+	cfg.TLSCACert = "./test/does-not-exist.pem"
+	is.True(cfg.Validate() != nil)
+}
+
+func TestSource_TLSClientCert(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	certPath, keyPath, clientCert := createClientCert(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "mutual TLS resource")
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"tls.caCert":     string(caCert),
+		"tls.clientCert": certPath,
+		"tls.clientKey":  keyPath,
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "mutual TLS resource")
+}
+
+func TestConfig_TLSClientCertMismatch(t *testing.T) {
+	is := is.New(t)
+
+	certPath, _, _ := createClientCert(t)
+	_, otherKeyPath, _ := createClientCert(t)
+
+	cfg := Config{RetryBackoffFactor: 2, TLSClientCert: certPath}
+	is.True(cfg.Validate() != nil) // key is missing
+
+	cfg.TLSClientKey = otherKeyPath
+	is.True(cfg.Validate() != nil) // key doesn't match the certificate
+}
+
+// createClientCert creates a self-signed client certificate and writes it and
+// its key as PEM files into a temporary directory.
+func createClientCert(t *testing.T) (certPath, keyPath string, cert *x509.Certificate) {
+	is := is.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	is.NoErr(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "conduit-connector-http"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	is.NoErr(err)
+	cert, err = x509.ParseCertificate(certDER)
+	is.NoErr(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	is.NoErr(err)
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "client.crt")
+	keyPath = filepath.Join(dir, "client.key")
+	err = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600)
+	is.NoErr(err)
+	err = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600)
+	is.NoErr(err)
+
+	return certPath, keyPath, cert
+}
+
+func TestSource_HTTP2(t *testing.T) {
+	ctx := context.Background()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Proto)
+	})
+	h2cServer := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	t.Cleanup(h2cServer.Close)
+	tlsServer := httptest.NewUnstartedServer(handler)
+	tlsServer.EnableHTTP2 = true
+	tlsServer.StartTLS()
+	t.Cleanup(tlsServer.Close)
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsServer.Certificate().Raw})
+
+	testCases := []struct {
+		name string
+		cfg  map[string]string
+		want string
+	}{{
+		name: "cleartext default",
+		cfg:  map[string]string{"url": h2cServer.URL},
+		want: "HTTP/1.1",
+	}, {
+		name: "cleartext http2",
+		cfg:  map[string]string{"url": h2cServer.URL, "http2": "true"},
+		want: "HTTP/2.0",
+	}, {
+		name: "tls http2",
+		cfg:  map[string]string{"url": tlsServer.URL, "tls.caCert": string(caCert), "http2": "true"},
+		want: "HTTP/2.0",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.NoErr(err)
+
+			rec, err := src.Read(ctx)
+			is.NoErr(err)
+			is.Equal(string(rec.Payload.After.Bytes()), tc.want)
+		})
+	}
+}
+
+func TestSource_TransportPool(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	src := &Source{}
+	err := src.Configure(ctx, map[string]string{
+		"url":                           "http://localhost:8080",
+		"transport.maxIdleConns":        "50",
+		"transport.maxIdleConnsPerHost": "10",
+		"transport.idleConnTimeout":     "15s",
+	})
+	is.NoErr(err)
+
+	transport, err := src.config.newTransport()
+	is.NoErr(err)
+	is.Equal(transport.MaxIdleConns, 50)
+	is.Equal(transport.MaxIdleConnsPerHost, 10)
+	is.Equal(transport.IdleConnTimeout, 15*time.Second)
+
+	err = (&Source{}).Configure(ctx, map[string]string{
+		"url":                           "http://localhost:8080",
+		"transport.maxIdleConnsPerHost": "0",
+	})
+	is.True(err != nil)
+}
+
+func TestSource_Redirects(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "/target", http.StatusFound)
+		case "/target":
+			fmt.Fprint(w, "target resource")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name    string
+		cfg     map[string]string
+		want    string
+		wantErr bool
+	}{{
+		name: "follow",
+		cfg:  map[string]string{},
+		want: "target resource",
+	}, {
+		name: "don't follow",
+		cfg: map[string]string{
+			"followRedirects": "false",
+			"expectedStatus":  "200-399",
+		},
+		want: "<a href=\"/target\">Found</a>.\n\n",
+	}, {
+		name:    "max redirects",
+		cfg:     map[string]string{"maxRedirects": "0"},
+		wantErr: true,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tc.cfg["url"] = server.URL + "/redirect"
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			if tc.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+
+			rec, err := src.Read(ctx)
+			is.NoErr(err)
+			is.Equal(string(rec.Payload.After.Bytes()), tc.want)
+		})
+	}
+}
+
+func TestSource_Cookies(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			// session is created by the connection test
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+			return
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "session resource")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":     server.URL,
+		"cookies": "true",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "session resource")
+}
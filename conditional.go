@@ -0,0 +1,124 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	lastModifiedFromHeader   = "header"
+	lastModifiedFromPosition = "position"
+	lastModifiedFromMetadata = "metadata:"
+)
+
+// validateConditional validates the options of conditional requests.
+func (s *SourceConfig) validateConditional() error {
+	from := s.ConditionalRequestsLastModifiedFrom
+	if from == "" {
+		return nil
+	}
+	if !s.ConditionalRequests {
+		return errors.New("conditionalRequests.lastModifiedFrom requires conditionalRequests to be enabled")
+	}
+	if from != lastModifiedFromHeader && from != lastModifiedFromPosition &&
+		(!strings.HasPrefix(from, lastModifiedFromMetadata) || from == lastModifiedFromMetadata) {
+		return fmt.Errorf("invalid conditionalRequests.lastModifiedFrom %q, expected header, position or metadata:<key>", from)
+	}
+	return nil
+}
+
+// requestHeader returns the headers of the next request, which are the
+// configured headers overridden by the headers returned by getRequestData.
+// When conditional requests are enabled, the validators of the previous
+// response are added, so that the server can reply with 304 Not Modified if
+// nothing changed. Only the first page of a poll is requested conditionally,
+// since the validators are stored for that page.
+func (s *Source) requestHeader(reqData *Request, firstPage bool) http.Header {
+	conditional := s.config.ConditionalRequests && firstPage && (s.lastETag != "" || s.lastModified != "")
+	if !conditional && len(reqData.Headers) == 0 {
+		return s.header
+	}
+	header := s.header.Clone()
+	for key, val := range reqData.Headers {
+		header.Set(key, val)
+	}
+	if conditional && s.lastETag != "" {
+		header.Set("If-None-Match", s.lastETag)
+	}
+	if conditional && s.lastModified != "" {
+		header.Set("If-Modified-Since", s.lastModified)
+	}
+	return header
+}
+
+// updateValidators stores the validators of a successful response, which are
+// sent with the next conditional request. The time sent in If-Modified-Since is
+// taken from the response or from the last of the records parsed from it,
+// depending on conditionalRequests.lastModifiedFrom.
+func (s *Source) updateValidators(resp *http.Response, records []opencdc.Record, firstPage bool) {
+	if !s.config.ConditionalRequests {
+		return
+	}
+	from := s.config.ConditionalRequestsLastModifiedFrom
+	if firstPage {
+		s.lastETag = resp.Header.Get("ETag")
+		if from == lastModifiedFromHeader {
+			s.lastModified = formatLastModified(resp.Header.Get("Last-Modified"))
+		}
+	}
+	if len(records) == 0 {
+		return
+	}
+	last := records[len(records)-1]
+	switch {
+	case from == lastModifiedFromPosition:
+		s.lastModified = formatLastModified(string(last.Position))
+	case strings.HasPrefix(from, lastModifiedFromMetadata):
+		s.lastModified = formatLastModified(last.Metadata[strings.TrimPrefix(from, lastModifiedFromMetadata)])
+	}
+}
+
+// formatLastModified formats a timestamp as an HTTP date, as sent in the
+// If-Modified-Since header. The timestamp can be an HTTP date, an RFC 3339
+// timestamp, a Unix timestamp in seconds, or the position of a record that
+// doesn't have a position of its own (`unix-<nanoseconds>`). An empty string is
+// returned if the timestamp can't be parsed.
+func formatLastModified(val string) string {
+	val = strings.TrimSpace(val)
+	if nanos, ok := strings.CutPrefix(val, "unix-"); ok {
+		if nsec, err := strconv.ParseInt(nanos, 10, 64); err == nil {
+			return time.Unix(0, nsec).UTC().Format(http.TimeFormat)
+		}
+		return ""
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		return t.UTC().Format(http.TimeFormat)
+	}
+	if t, err := time.Parse(time.RFC3339Nano, val); err == nil {
+		return t.UTC().Format(http.TimeFormat)
+	}
+	if sec, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC().Format(http.TimeFormat)
+	}
+	return ""
+}
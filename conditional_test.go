@@ -0,0 +1,215 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/matryer/is"
+)
+
+func TestSource_ConditionalRequests(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "version 1")
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := map[string]string{
+		"url":                 server.URL,
+		"conditionalRequests": "true",
+		"pollingPeriod":       "1ms",
+	}
+	src := NewSource()
+	err := src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "version 1")
+
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, sdk.ErrBackoffRetry))
+
+	// the ETag is restored from the position
+	src = NewSource()
+	err = src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, rec.Position)
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, sdk.ErrBackoffRetry))
+}
+
+func TestSource_ConditionalRequestsLastModified(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  map[string]string
+	}{{
+		name: "header",
+		cfg:  map[string]string{"conditionalRequests.lastModifiedFrom": "header"},
+	}, {
+		name: "position",
+		cfg: map[string]string{
+			"conditionalRequests.lastModifiedFrom": "position",
+			"response.recordsPath":                 "items",
+			"response.keyPath":                     "updatedAt",
+		},
+	}, {
+		name: "metadata",
+		cfg:  map[string]string{"conditionalRequests.lastModifiedFrom": "metadata:Last-Modified"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			var ifModifiedSince []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					return
+				}
+				ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+				if r.Header.Get("If-Modified-Since") != "" {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Last-Modified", "Tue, 02 Jan 2024 10:00:00 GMT")
+				fmt.Fprint(w, `{"items": [{"updatedAt": "2024-01-01T10:00:00Z"}, {"updatedAt": "2024-01-02T10:00:00Z"}]}`)
+			}))
+			t.Cleanup(server.Close)
+
+			tc.cfg["url"] = server.URL
+			tc.cfg["conditionalRequests"] = "true"
+			tc.cfg["pollingPeriod"] = "1ms"
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.NoErr(err)
+
+			rec, err := src.Read(ctx)
+			is.NoErr(err)
+			if tc.cfg["response.recordsPath"] != "" {
+				rec, err = src.Read(ctx)
+				is.NoErr(err)
+			}
+			_, err = src.Read(ctx)
+			is.True(errors.Is(err, sdk.ErrBackoffRetry))
+			is.Equal(ifModifiedSince, []string{"", "Tue, 02 Jan 2024 10:00:00 GMT"})
+
+			// the time is restored from the position
+			src = NewSource()
+			err = src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, rec.Position)
+			is.NoErr(err)
+
+			_, err = src.Read(ctx)
+			is.True(errors.Is(err, sdk.ErrBackoffRetry))
+			is.Equal(ifModifiedSince[2], "Tue, 02 Jan 2024 10:00:00 GMT")
+		})
+	}
+}
+
+func TestSource_ConditionalRequestsLastModifiedUnixPosition(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var ifModifiedSince []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+		if r.Header.Get("If-Modified-Since") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                                  server.URL,
+		"conditionalRequests":                  "true",
+		"conditionalRequests.lastModifiedFrom": "position",
+		"pollingPeriod":                        "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	start := time.Now().Truncate(time.Second)
+	_, err = src.Read(ctx)
+	is.NoErr(err)
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, sdk.ErrBackoffRetry))
+
+	// the time is taken from the unix-<nanoseconds> position of the record
+	is.Equal(len(ifModifiedSince), 2)
+	got, err := http.ParseTime(ifModifiedSince[1])
+	is.NoErr(err)
+	is.True(!got.Before(start))
+}
+
+func TestSourceConfig_ValidateConditional(t *testing.T) {
+	is := is.New(t)
+
+	for _, from := range []string{"", "header", "position", "metadata:updatedAt"} {
+		cfg := SourceConfig{ConditionalRequests: true, ConditionalRequestsLastModifiedFrom: from}
+		is.NoErr(cfg.validateConditional())
+	}
+	for _, cfg := range []SourceConfig{
+		{ConditionalRequestsLastModifiedFrom: "header"},
+		{ConditionalRequests: true, ConditionalRequestsLastModifiedFrom: "metadata:"},
+		{ConditionalRequests: true, ConditionalRequestsLastModifiedFrom: "body"},
+	} {
+		is.True(cfg.validateConditional() != nil)
+	}
+}
+
+func TestFormatLastModified(t *testing.T) {
+	is := is.New(t)
+
+	want := "Tue, 02 Jan 2024 10:00:00 GMT"
+	is.Equal(formatLastModified("Tue, 02 Jan 2024 10:00:00 GMT"), want)
+	is.Equal(formatLastModified("2024-01-02T11:00:00+01:00"), want)
+	is.Equal(formatLastModified("1704189600"), want)
+	is.Equal(formatLastModified("unix-1704189600123456789"), want)
+	is.Equal(formatLastModified("not a time"), "")
+}
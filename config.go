@@ -15,17 +15,243 @@
 package http
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
+	"unicode"
 )
 
 type Config struct {
-	// Http headers to use in the request, comma separated list of : separated pairs
+	// Http headers to use in the request, comma separated list of : separated pairs. The value is
+	// everything after the first colon, e.g. `Authorization:Bearer x:y`, and a header that's
+	// listed multiple times gets all the values.
 	Headers []string
+	// Http headers to use in the request, use headersMap.* as the config key and specify its
+	// value, e.g. set `headersMap.Authorization` to `Bearer x:y`. Merged with headers, which
+	// is convenient for values containing commas.
+	HeadersMap map[string]string `json:"headersMap"`
 	// parameters to use in the request, use params.* as the config key and specify its value, ex: set "params.id" as "1".
 	Params map[string]string
+	// Separator used to split param values into multiple query parameters with the same
+	// name, e.g. with `,` the param `params.tag` set to `a,b` results in `tag=a&tag=b`.
+	// When empty, param values aren't split.
+	ParamsSeparator string `json:"paramsSeparator"`
+	// Maximum time a single request can take, including reading the response body.
+	// Zero means no timeout.
+	RequestTimeout time.Duration `json:"requestTimeout" default:"30s"`
+	// Whether requests are sent using HTTP/2 only. Requests to https urls negotiate HTTP/2
+	// during the TLS handshake, requests to http urls use HTTP/2 over cleartext (h2c) with
+	// prior knowledge. Proxies aren't supported in this mode. When disabled, HTTP/2 is used
+	// if the server supports it over TLS, otherwise HTTP/1.1.
+	HTTP2 bool `json:"http2" default:"false"`
+	// Maximum number of idle (keep-alive) connections across all hosts. Zero means no limit.
+	TransportMaxIdleConns int `json:"transport.maxIdleConns" default:"100" validate:"gt=-1"`
+	// Maximum number of idle (keep-alive) connections kept per host. Raising it lets
+	// concurrent requests reuse connections instead of opening new ones.
+	TransportMaxIdleConnsPerHost int `json:"transport.maxIdleConnsPerHost" default:"2" validate:"gt=0"`
+	// Time after which an idle (keep-alive) connection is closed. Zero means no limit.
+	TransportIdleConnTimeout time.Duration `json:"transport.idleConnTimeout" default:"90s"`
+	// Whether a request is sent to check the connection when the connector starts.
+	ValidateConnection bool `json:"validateConnection" default:"true"`
+	// Http method of the request sent to check the connection when the connector starts.
+	// The request is sent without a body.
+	ValidateConnectionMethod string `json:"validateConnection.method" default:"HEAD" validate:"inclusion=HEAD|GET|OPTIONS"`
+	// Whether redirect responses are followed. When disabled, a redirect response is
+	// returned as-is and checked against expectedStatus.
+	FollowRedirects bool `json:"followRedirects" default:"true"`
+	// Maximum number of redirects followed for a single request.
+	MaxRedirects int `json:"maxRedirects" default:"10"`
+	// Whether cookies set by the server are stored and sent with subsequent requests,
+	// including the ones following the connection test. Cookies are kept in memory
+	// for as long as the connector is running.
+	Cookies bool `json:"cookies" default:"false"`
+	// Whether metrics about the sent requests (counts by status code and method, and a
+	// histogram of durations) are recorded. The metrics are published using expvar under
+	// the name `conduit_connector_http`.
+	MetricsEnabled bool `json:"metrics.enabled" default:"false"`
+
+	// Whether the sent requests (method, URL, headers and body) are logged at trace level,
+	// for debugging. The values of the redact.headers and redact.queryParams and the
+	// configured credentials are redacted. OAuth2 token requests are not logged.
+	LogRequests bool `json:"logRequests" default:"false"`
+	// Whether the received responses (status, duration, headers and body) are logged at trace
+	// level, for debugging. The values of the redact.headers and the configured credentials
+	// are redacted. Responses to OAuth2 token requests are not logged.
+	LogResponses bool `json:"logResponses" default:"false"`
+	// Whether the query of the URL is redacted in logged requests.
+	LogRedactQuery bool `json:"logRedactQuery" default:"false"`
+	// Maximum number of bytes of a request or response body that are logged.
+	LogMaxBodyBytes int `json:"logMaxBodyBytes" default:"1024"`
+	// Names of headers whose values are replaced with `***` in logs, comma separated list.
+	RedactHeaders []string `json:"redact.headers" default:"Authorization,Proxy-Authorization,Cookie,Set-Cookie"`
+	// Names of query parameters whose values are replaced with `***` in logs and error
+	// messages, comma separated list.
+	RedactQueryParams []string `json:"redact.queryParams" default:"api_key,token"`
+
+	// Maximum number of attempts for a request that fails with a transient network error (a
+	// timeout, or a refused, reset or closed connection) or with one of the retry.statusCodes,
+	// including the first attempt. Network errors are only retried for GET, HEAD, OPTIONS and
+	// TRACE requests, and for requests of the destination with an idempotencyKey, since other
+	// requests might have been processed by the server already.
+	RetryMaxAttempts int `json:"retry.maxAttempts" default:"3" validate:"gt=0"`
+	// Delay before retrying a failed request for the first time. The Retry-After response
+	// header takes precedence when present, a delay longer than 5 minutes is shortened.
+	RetryBackoff time.Duration `json:"retry.backoff" default:"1s"`
+	// Factor by which the delay is multiplied after each retry, needs to be at least 1.
+	RetryBackoffFactor float64 `json:"retry.backoffFactor" default:"2"`
+	// Response status codes for which the request is retried, comma separated list. Other unexpected status codes are treated as fatal and are not retried.
+	RetryStatusCodes []int `json:"retry.statusCodes" default:"429,502,503,504"`
+
+	// Maximum number of requests sent per second on average, including retries, the
+	// requests for further pages and the requests sent in parallel. Requests wait until
+	// they're allowed to be sent. Zero means no limit.
+	RateLimitPerSecond float64 `json:"rateLimit.perSecond" default:"0"`
+	// Maximum number of requests sent at once when the rate limit allows it, e.g. after
+	// the connector was idle, used with rateLimit.perSecond.
+	RateLimitBurst int `json:"rateLimit.burst" default:"1" validate:"gt=0"`
+	// Whether the rate limit applies to each host separately instead of to all requests of
+	// the connector. The limit of a host is shared by all connectors in the same process
+	// that enable this option, so the aggregate rate of requests to the host stays within
+	// it, regardless of the urls. If the connectors configure different limits, the most
+	// restrictive one applies.
+	RateLimitPerHost bool `json:"rateLimit.perHost" default:"false"`
+
+	// Username used for HTTP Basic Authentication, requires auth.basic.password to be set too.
+	BasicAuthUsername string `json:"auth.basic.username"`
+	// Password used for HTTP Basic Authentication, requires auth.basic.username to be set too.
+	BasicAuthPassword string `json:"auth.basic.password"`
+
+	// URL of the OAuth2 token endpoint. When set, the connector obtains an access token
+	// using the OAuth2 client credentials flow, or the refresh token flow if
+	// auth.oauth2.refreshToken is set, and refreshes it before it expires.
+	OAuth2TokenURL string `json:"auth.oauth2.tokenURL"`
+	// Client ID used to obtain OAuth2 access tokens.
+	OAuth2ClientID string `json:"auth.oauth2.clientID"`
+	// Client secret used to obtain OAuth2 access tokens.
+	OAuth2ClientSecret string `json:"auth.oauth2.clientSecret"`
+	// Scopes requested in the OAuth2 client credentials flow, comma separated list.
+	OAuth2Scopes []string `json:"auth.oauth2.scopes"`
+	// Refresh token used to obtain OAuth2 access tokens with the refresh token flow instead
+	// of the client credentials flow. If the server rotates the refresh token, the new one
+	// is used for the following refreshes for as long as the connector is running, after a
+	// restart the configured token is used again.
+	OAuth2RefreshToken string `json:"auth.oauth2.refreshToken"`
+	// Auth contains the auth options that are maps. They're nested, because map parameters
+	// with dots in their name can't be decoded.
+	Auth authConfig `json:"auth"`
+
+	// API key sent with every request, in the header auth.apiKey.header or in the query
+	// parameter auth.apiKey.queryParam. The key is redacted in logs and error messages.
+	APIKeyValue string `json:"auth.apiKey.value"`
+	// Name of the header containing the API key, e.g. `X-API-Key`.
+	APIKeyHeader string `json:"auth.apiKey.header"`
+	// Name of the query parameter containing the API key, e.g. `api_key`.
+	APIKeyQueryParam string `json:"auth.apiKey.queryParam"`
+
+	// CA certificate used to verify the server's certificate, either the path to a
+	// PEM encoded file or the PEM encoded certificate itself. When not set, the
+	// system's root CAs are used.
+	TLSCACert string `json:"tls.caCert"`
+	// Path to a PEM encoded client certificate used for mutual TLS, requires tls.clientKey to be set too.
+	TLSClientCert string `json:"tls.clientCert"`
+	// Path to the PEM encoded private key of the client certificate, requires tls.clientCert to be set too.
+	TLSClientKey string `json:"tls.clientKey"`
+	// Skip verifying the server's certificate chain and host name. This should only
+	// be used for testing, as it makes the connection vulnerable to man-in-the-middle attacks.
+	TLSInsecureSkipVerify bool `json:"tls.insecureSkipVerify" default:"false"`
+}
+
+// authConfig contains the options under auth. that are decoded into a nested
+// struct.
+type authConfig struct {
+	OAuth2 oauth2Config `json:"oauth2"`
+}
+
+// oauth2Config contains the options under auth.oauth2. that are decoded into a
+// nested struct.
+type oauth2Config struct {
+	// Additional parameters sent in the body of OAuth2 client credentials token requests, use
+	// auth.oauth2.extraParams.* as the config key and specify its value, e.g. set
+	// `auth.oauth2.extraParams.audience` to `https://api.example.com`. Can't be used with
+	// auth.oauth2.refreshToken.
+	ExtraParams map[string]string `json:"extraParams"`
+}
+
+// Validate checks the combination of config values that can't be expressed
+// through parameter validations.
+func (s *Config) Validate() error {
+	if s.RetryMaxAttempts > 1 && s.RetryBackoffFactor < 1 {
+		return fmt.Errorf("retry.backoffFactor needs to be at least 1, got %v", s.RetryBackoffFactor)
+	}
+	if s.RateLimitPerSecond < 0 {
+		return fmt.Errorf("rateLimit.perSecond needs to be greater than or equal to 0, got %v", s.RateLimitPerSecond)
+	}
+	if (s.BasicAuthUsername == "") != (s.BasicAuthPassword == "") {
+		return errors.New("both auth.basic.username and auth.basic.password need to be set for basic authentication")
+	}
+	if err := s.validateOAuth2(); err != nil {
+		return err
+	}
+	if s.APIKeyValue != "" && (s.APIKeyHeader == "") == (s.APIKeyQueryParam == "") {
+		return errors.New("auth.apiKey.value requires exactly one of auth.apiKey.header or auth.apiKey.queryParam to be set")
+	}
+	if s.APIKeyValue == "" && (s.APIKeyHeader != "" || s.APIKeyQueryParam != "") {
+		return errors.New("auth.apiKey.header and auth.apiKey.queryParam require auth.apiKey.value to be set")
+	}
+	if err := s.validateParams(); err != nil {
+		return err
+	}
+	if _, err := s.tlsConfig(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateOAuth2 checks that either none or all of the required OAuth2 options
+// are set.
+func (s *Config) validateOAuth2() error {
+	if s.OAuth2TokenURL == "" && s.OAuth2ClientID == "" && s.OAuth2ClientSecret == "" &&
+		s.OAuth2RefreshToken == "" && len(s.Auth.OAuth2.ExtraParams) == 0 {
+		return nil
+	}
+	if s.OAuth2TokenURL == "" || s.OAuth2ClientID == "" || s.OAuth2ClientSecret == "" {
+		return errors.New("auth.oauth2.tokenURL, auth.oauth2.clientID and auth.oauth2.clientSecret need to be set for OAuth2 authentication")
+	}
+	if s.BasicAuthUsername != "" {
+		return errors.New("basic authentication and OAuth2 authentication can't be used at the same time")
+	}
+	if len(s.Auth.OAuth2.ExtraParams) > 0 && s.OAuth2RefreshToken != "" {
+		return errors.New("auth.oauth2.extraParams can't be used with auth.oauth2.refreshToken, it's only sent in the client credentials flow")
+	}
+	for key := range s.Auth.OAuth2.ExtraParams {
+		// set by the client credentials flow itself
+		if key == "grant_type" || key == "scope" {
+			return fmt.Errorf("auth.oauth2.extraParams.%s can't be set, use auth.oauth2.scopes for the scopes", key)
+		}
+	}
+	return nil
+}
+
+// validateParams checks that the names of the params are usable as query
+// parameter names. Values don't need to be checked, as they are escaped when
+// they are added to the URL.
+func (s *Config) validateParams() error {
+	for key := range s.Params {
+		switch {
+		case strings.TrimSpace(key) == "":
+			return errors.New("params contains a parameter with an empty name")
+		case strings.ContainsAny(key, "&=?#"):
+			return fmt.Errorf("params contains the parameter name %q, names can't contain any of the characters &=?#", key)
+		case strings.ContainsFunc(key, unicode.IsControl):
+			return fmt.Errorf("params contains the parameter name %q, names can't contain control characters", key)
+		}
+	}
+	return nil
 }
 
 func (s *Config) addParamsToURL(origURL string) (string, error) {
@@ -37,7 +263,16 @@ func (s *Config) addParamsToURL(origURL string) (string, error) {
 	existingParams := parsedURL.Query()
 	// Add config params
 	for key, val := range s.Params {
-		existingParams.Add(key, val)
+		if s.ParamsSeparator == "" {
+			existingParams.Add(key, val)
+			continue
+		}
+		for _, v := range strings.Split(val, s.ParamsSeparator) {
+			existingParams.Add(key, strings.TrimSpace(v))
+		}
+	}
+	if s.APIKeyQueryParam != "" {
+		existingParams.Set(s.APIKeyQueryParam, s.APIKeyValue)
 	}
 	// Update query parameters in the URL struct
 	parsedURL.RawQuery = existingParams.Encode()
@@ -64,5 +299,16 @@ func (s *Config) getHeader() (http.Header, error) {
 		// Add to header
 		header.Add(key, value)
 	}
+	for key, value := range s.HeadersMap {
+		header.Add(key, value)
+	}
+
+	if s.BasicAuthUsername != "" {
+		credentials := s.BasicAuthUsername + ":" + s.BasicAuthPassword
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+	if s.APIKeyHeader != "" {
+		header.Set(s.APIKeyHeader, s.APIKeyValue)
+	}
 	return header, nil
 }
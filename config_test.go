@@ -78,3 +78,140 @@ func TestConfig_Headers(t *testing.T) {
 	is.True(got.Get("header1") == want.Get("header1"))
 	is.True(got.Get("header2") == want.Get("header2"))
 }
+
+func TestConfig_HeadersWithColons(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		Headers: []string{
+			"Authorization: Bearer x:y",
+			"X-Time:12:30:00",
+			"Accept:application/json",
+			"accept:text/plain",
+		},
+	}
+	got, err := config.getHeader()
+	is.NoErr(err)
+	is.Equal(got.Get("Authorization"), "Bearer x:y")
+	is.Equal(got.Get("X-Time"), "12:30:00")
+	is.Equal(got.Values("Accept"), []string{"application/json", "text/plain"})
+
+	config.Headers = []string{"Authorization"}
+	_, err = config.getHeader()
+	is.True(err != nil)
+}
+
+func TestConfig_HeadersMap(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		Headers: []string{"X-Tag:a"},
+		HeadersMap: map[string]string{
+			"x-tag":         "b,c",
+			"Authorization": "Bearer x:y",
+		},
+	}
+	got, err := config.getHeader()
+	is.NoErr(err)
+	is.Equal(got.Values("X-Tag"), []string{"a", "b,c"})
+	is.Equal(got.Get("Authorization"), "Bearer x:y")
+}
+
+func TestConfig_BasicAuth(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		Headers:           []string{"header1:val1"},
+		BasicAuthUsername: "user",
+		BasicAuthPassword: "pass",
+	}
+	is.NoErr(config.Validate())
+
+	got, err := config.getHeader()
+	is.NoErr(err)
+	is.Equal(got.Get("header1"), "val1")
+	is.Equal(got.Get("Authorization"), "Basic dXNlcjpwYXNz")
+}
+
+func TestConfig_BasicAuthMissingPassword(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		BasicAuthUsername: "user",
+	}
+	is.True(config.Validate() != nil)
+}
+
+func TestConfig_OAuth2RefreshTokenWithoutTokenURL(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		RetryBackoffFactor: 2,
+		OAuth2RefreshToken: "refresh",
+	}
+	is.True(config.Validate() != nil)
+}
+
+func TestConfig_OAuth2ExtraParams(t *testing.T) {
+	base := Config{
+		RetryBackoffFactor: 2,
+		OAuth2TokenURL:     "https://example.com/token",
+		OAuth2ClientID:     "id",
+		OAuth2ClientSecret: "secret",
+	}
+	testCases := []struct {
+		name         string
+		params       map[string]string
+		refreshToken string
+		wantErr      bool
+	}{
+		{name: "audience", params: map[string]string{"audience": "api"}},
+		{name: "grant type", params: map[string]string{"grant_type": "password"}, wantErr: true},
+		{name: "scope", params: map[string]string{"scope": "read"}, wantErr: true},
+		{name: "refresh token", params: map[string]string{"audience": "api"}, refreshToken: "refresh", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			config := base
+			config.Auth.OAuth2.ExtraParams = tc.params
+			config.OAuth2RefreshToken = tc.refreshToken
+			is.Equal(config.Validate() != nil, tc.wantErr)
+		})
+	}
+}
+
+func TestConfig_ParamsEscaping(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		Params: map[string]string{
+			"q":          "a&b=c d",
+			"first name": "John Doe",
+		},
+	}
+	is.NoErr(config.validateParams())
+
+	got, err := config.addParamsToURL("http://localhost:8082/resource")
+	is.NoErr(err)
+	is.Equal(got, "http://localhost:8082/resource?first+name=John+Doe&q=a%26b%3Dc+d")
+}
+
+func TestConfig_InvalidParams(t *testing.T) {
+	is := is.New(t)
+	for _, key := range []string{"", " ", "a&b", "a=b", "a?b", "a#b", "a\nb"} {
+		config := Config{Params: map[string]string{key: "1"}}
+		is.True(config.validateParams() != nil) // invalid param name
+	}
+}
+
+func TestConfig_ParamsSeparator(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		Params: map[string]string{
+			"tag": "a, b,c",
+		},
+	}
+	got, err := config.addParamsToURL("http://localhost:8082/resource")
+	is.NoErr(err)
+	is.Equal(got, "http://localhost:8082/resource?tag=a%2C+b%2Cc")
+
+	config.ParamsSeparator = ","
+	got, err = config.addParamsToURL("http://localhost:8082/resource?tag=x")
+	is.NoErr(err)
+	is.Equal(got, "http://localhost:8082/resource?tag=x&tag=a&tag=b&tag=c")
+}
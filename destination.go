@@ -18,13 +18,22 @@ package http
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/conduitio/conduit-commons/config"
@@ -32,13 +41,39 @@ import (
 	sdk "github.com/conduitio/conduit-connector-sdk"
 )
 
+const (
+	compressionGzip = "gzip"
+	onErrorSkip     = "skip"
+
+	onEmptyPayloadSkip  = "skip"
+	onEmptyPayloadError = "error"
+
+	// maxErrorBodyBytes is the maximum number of bytes of a rejected
+	// response body that are included in the error.
+	maxErrorBodyBytes = 1024
+)
+
 type Destination struct {
 	sdk.UnimplementedDestination
 
-	config  DestinationConfig
-	client  *http.Client
-	header  http.Header
-	urlTmpl *template.Template
+	config      DestinationConfig
+	client      *http.Client
+	header      http.Header
+	headerTmpls []headerTemplate
+	urlTmpl     *template.Template
+
+	graphQLMutationTmpl  *template.Template
+	graphQLVariablesTmpl *template.Template
+
+	multipartFields []multipartField
+
+	expectedStatus statusRanges
+}
+
+// headerTemplate is a header with a value that's evaluated for each record.
+type headerTemplate struct {
+	key  string
+	tmpl *template.Template
 }
 
 type DestinationConfig struct {
@@ -46,10 +81,88 @@ type DestinationConfig struct {
 	// URL is a Go template expression for the URL used in the HTTP request, using Go [templates](https://pkg.go.dev/text/template).
 	// The value provided to the template is [opencdc.Record](https://github.com/ConduitIO/conduit-connector-sdk/blob/bfc1d83eb75460564fde8cb4f8f96318f30bd1b4/record.go#L81),
 	// so the template has access to all its fields (e.g. .Position, .Key, .Metadata, and so on). We also inject all template functions provided by [sprig](https://masterminds.github.io/sprig/)
-	// to make it easier to write templates.
+	// to make it easier to write templates. Values used as a path segment or as a query parameter should be
+	// escaped using `pathEscape` or `queryEscape`, e.g. `/items/{{ .Payload.After.id | pathEscape }}`, so that
+	// characters like `/` or `&` don't change the URL. The record key and payload are `opencdc.Data`, use
+	// `dataString` to render them as a string, e.g. `/items/{{ dataString .Key | pathEscape }}`.
 	URL string `json:"url" validate:"required"`
-	// Http method to use in the request
+	// Http method to use in the request. The request body is the same for all methods, so a
+	// DELETE request contains the payload of the record, if it has one.
 	Method string `default:"POST" validate:"inclusion=POST|PUT|DELETE|PATCH"`
+	// Compression applied to the request body, the `Content-Encoding` header is set
+	// accordingly. When empty, the body is sent uncompressed.
+	Compression string `json:"compression" validate:"inclusion=gzip"`
+	// Whether the request body is streamed using chunked transfer encoding, without a
+	// Content-Length header. With compression, the body is compressed while it's sent,
+	// which avoids holding a compressed copy of large payloads in memory.
+	Streaming bool `json:"streaming" default:"false"`
+	// Response status codes that are considered successful, as a list of codes or ranges,
+	// e.g. `200-299,404`. Responses with other status codes result in an error.
+	ExpectedStatus []string `json:"expectedStatus" default:"200-399"`
+	// Maximum number of requests sent in parallel. With a value greater than 1, the
+	// records of a batch may reach the server in a different order than they were written.
+	Concurrency int `json:"concurrency" default:"1" validate:"gt=0"`
+	// Name of the header containing an idempotency key, e.g. `Idempotency-Key`. The key is
+	// derived from the record's key and position, so it's the same when a request is retried
+	// or a record is written again, which lets the server discard duplicates. When empty, no
+	// idempotency key is sent.
+	IdempotencyKey string `json:"idempotencyKey"`
+	// Name of the header containing the record key, e.g. `X-Record-Key`. A raw key is sent as is,
+	// a structured key is sent as JSON. When empty, or when the record has no key, the header
+	// isn't sent.
+	KeyHeader string `json:"keyHeader"`
+	// What happens when the server rejects a record by responding with an unexpected status,
+	// after all retries. With `fail`, the write fails and the pipeline stops. With `skip`, the
+	// error is logged and the record is skipped. Other errors, e.g. network errors, always fail
+	// the write.
+	OnError string `json:"onError" default:"fail" validate:"inclusion=fail|skip"`
+	// What happens with a record without a payload (`Payload.After`) when using a method that
+	// requires a body (POST, PUT or PATCH). With `send`, the request is sent without a body.
+	// With `skip`, the record is logged and skipped. With `error`, the write fails.
+	OnEmptyPayload string `json:"onEmptyPayload" default:"send" validate:"inclusion=send|skip|error"`
+	// Whether the body of successful responses is logged together with the key and position of
+	// the record, e.g. to capture IDs assigned by the server. The body is truncated to
+	// logMaxBodyBytes.
+	CaptureResponse bool `json:"captureResponse" default:"false"`
+
+	// GraphQL mutation sent for each record, using Go templates with the same data and
+	// functions as url. When set, the request body contains the mutation instead of the
+	// record payload, and errors in the GraphQL response are treated as failures, even
+	// with the status 200 OK. Requires the method POST.
+	GraphQLMutation string `json:"graphql.mutation"`
+	// Variables of the GraphQL mutation, using Go templates with the same data and functions
+	// as url. The result needs to be a JSON object, e.g. `{"input": {{ toJson .Payload.After }}}`.
+	GraphQLVariables string `json:"graphql.variables"`
+
+	// Encoding of the request body. With `multipart`, the body is sent as `multipart/form-data`,
+	// with a file part containing the record payload and optional parts containing metadata.
+	// With `form`, the body is sent as `application/x-www-form-urlencoded`, where each field of
+	// a structured payload becomes a form field, lists become repeated fields and nested objects
+	// are encoded as JSON. A raw payload is sent as a single field named after form.rawField.
+	// In both cases the `Content-Type` header is set automatically. Any other value is a media
+	// type, e.g. `application/xml; charset=utf-8`, sent as the `Content-Type` of the payload
+	// unless the header is set in headers. When empty, the type is inferred from the payload:
+	// structured payloads and raw payloads containing valid JSON are sent as `application/json`,
+	// other raw payloads are sniffed, e.g. `text/plain; charset=utf-8` for text and
+	// `application/octet-stream` for binary data.
+	ContentType string `json:"contentType"`
+	// Encoding of structured payloads sent as the request body. With `json`, the payload is
+	// encoded as JSON. With `cbor` or `msgpack`, it's encoded as CBOR or MessagePack and the
+	// `Content-Type` is `application/cbor` or `application/x-msgpack`, unless it's set in
+	// contentType or headers. Map keys are sorted with all encodings. Raw payloads are always
+	// sent as is. Can't be combined with contentType multipart or form, or graphql.mutation.
+	BodyEncoding string `json:"bodyEncoding" default:"json" validate:"inclusion=json|cbor|msgpack"`
+	// Name of the form field containing the record payload, used with contentType multipart.
+	MultipartFileField string `json:"multipart.fileField" default:"file"`
+	// File name of the part containing the record payload, used with contentType multipart.
+	MultipartFileName string `json:"multipart.fileName" default:"payload"`
+	// Metadata fields sent as additional parts with contentType multipart, as a comma separated
+	// list of metadata keys. A key can be followed by `:` and the name of the form field, e.g.
+	// `file.name:filename`, otherwise the key is used as the name. Fields missing in the record
+	// metadata are skipped.
+	MultipartMetadataFields []string `json:"multipart.metadataFields"`
+	// Name of the form field containing a raw payload, used with contentType form.
+	FormRawField string `json:"form.rawField" default:"payload"`
 }
 
 func NewDestination() sdk.Destination {
@@ -66,34 +179,198 @@ func (d *Destination) Configure(ctx context.Context, cfg config.Config) error {
 	if err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
+	err = d.config.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
 
+	d.expectedStatus, err = parseStatusRanges(d.config.ExpectedStatus)
+	if err != nil {
+		return fmt.Errorf("invalid expectedStatus: %w", err)
+	}
 	d.header, err = d.config.getHeader()
 	if err != nil {
 		return fmt.Errorf("invalid header config: %w", err)
 	}
+	d.headerTmpls, err = extractHeaderTemplates(d.header)
+	if err != nil {
+		return fmt.Errorf("invalid header config: %w", err)
+	}
 	if strings.Contains(d.config.URL, "{{") {
 		// create URL template
-		d.urlTmpl, err = template.New("").Funcs(sprig.FuncMap()).Parse(d.config.URL)
+		d.urlTmpl, err = template.New("").Funcs(templateFuncs()).Parse(d.config.URL)
 		if err != nil {
 			return fmt.Errorf("error while parsing the URL template: %w", err)
 		}
 	}
+
+	if d.config.GraphQLMutation != "" {
+		if d.config.Method != http.MethodPost {
+			return fmt.Errorf("invalid config: graphql.mutation requires the method %v", http.MethodPost)
+		}
+		d.graphQLMutationTmpl, err = template.New("").Funcs(templateFuncs()).Parse(d.config.GraphQLMutation)
+		if err != nil {
+			return fmt.Errorf("error while parsing the graphql.mutation template: %w", err)
+		}
+		d.graphQLVariablesTmpl, err = template.New("").Funcs(templateFuncs()).Parse(d.config.GraphQLVariables)
+		if err != nil {
+			return fmt.Errorf("error while parsing the graphql.variables template: %w", err)
+		}
+		if d.header.Get("Content-Type") == "" {
+			d.header.Set("Content-Type", "application/json")
+		}
+	} else if d.config.GraphQLVariables != "" {
+		return errors.New("invalid config: graphql.variables requires graphql.mutation to be set")
+	}
+
+	err = d.configureContentType()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
 	return nil
 }
 
+// configureContentType validates contentType and prepares the body encoding.
+func (d *Destination) configureContentType() error {
+	if d.config.BodyEncoding != bodyEncodingJSON {
+		if d.config.GraphQLMutation != "" {
+			return fmt.Errorf("bodyEncoding %v can't be used with graphql.mutation", d.config.BodyEncoding)
+		}
+		if d.config.ContentType == contentTypeMultipart || d.config.ContentType == contentTypeForm {
+			return fmt.Errorf("bodyEncoding %v can't be used with contentType %v", d.config.BodyEncoding, d.config.ContentType)
+		}
+	}
+	if d.config.ContentType == "" {
+		return nil
+	}
+	if d.config.GraphQLMutation != "" {
+		return fmt.Errorf("contentType %v can't be used with graphql.mutation", d.config.ContentType)
+	}
+	switch d.config.ContentType {
+	case contentTypeMultipart:
+		var err error
+		d.multipartFields, err = parseMultipartFields(d.config.MultipartMetadataFields)
+		return err
+	case contentTypeForm:
+		return nil
+	default:
+		_, _, err := mime.ParseMediaType(d.config.ContentType)
+		if err != nil {
+			return fmt.Errorf("contentType %q is neither multipart, form nor a valid media type: %w", d.config.ContentType, err)
+		}
+		return nil
+	}
+}
+
+// payloadContentType returns the Content-Type of a payload sent as is, which
+// is the configured media type, the type of the body encoding for structured
+// payloads, or the type inferred from the payload.
+func (d *Destination) payloadContentType(rec opencdc.Record) string {
+	if d.config.ContentType != "" {
+		return d.config.ContentType
+	}
+	if _, ok := rec.Payload.After.(opencdc.StructuredData); ok {
+		return bodyEncodingContentType(d.config.BodyEncoding)
+	}
+	return detectContentType(rec.Payload.After)
+}
+
+// detectContentType infers the media type of a payload. Structured payloads
+// are sent as JSON. Raw payloads that are valid JSON are detected as JSON,
+// other raw payloads are sniffed using http.DetectContentType, which falls
+// back to application/octet-stream for binary data. Nothing is returned for an
+// empty payload.
+func detectContentType(data opencdc.Data) string {
+	switch data := data.(type) {
+	case opencdc.StructuredData:
+		return "application/json"
+	case opencdc.RawData:
+		if len(data) == 0 {
+			return ""
+		}
+		if json.Valid(data) {
+			return "application/json"
+		}
+		return http.DetectContentType(data)
+	default:
+		return ""
+	}
+}
+
+// templateFuncs returns the functions available in templates, which are the
+// sprig functions and functions to escape values in URLs:
+//   - pathEscape escapes a value so that it's a single path segment, e.g.
+//     `a/b` becomes `a%2Fb`.
+//   - queryEscape escapes a value so that it's a single query parameter
+//     value, e.g. `a&b` becomes `a%26b`.
+//   - dataString returns record data (e.g. `.Key`) as a string, see dataString.
+//
+// The escape functions accept any value, numbers are formatted with their
+// exact value, see formatValue.
+func templateFuncs() template.FuncMap {
+	funcs := sprig.FuncMap()
+	funcs["pathEscape"] = func(v any) string { return url.PathEscape(formatValue(v)) }
+	funcs["queryEscape"] = func(v any) string { return url.QueryEscape(formatValue(v)) }
+	funcs["dataString"] = dataString
+	return funcs
+}
+
+// dataString returns the string form of record data: the bytes of raw data,
+// and the JSON encoding of structured data. Nil data results in an empty
+// string.
+func dataString(data opencdc.Data) string {
+	if data == nil {
+		return ""
+	}
+	return string(data.Bytes())
+}
+
+// extractHeaderTemplates removes the header values that contain a template
+// from the header and returns them as parsed templates.
+func extractHeaderTemplates(header http.Header) ([]headerTemplate, error) {
+	var tmpls []headerTemplate
+	for key, vals := range header {
+		var static []string
+		for _, val := range vals {
+			if !strings.Contains(val, "{{") {
+				static = append(static, val)
+				continue
+			}
+			tmpl, err := template.New(key).Funcs(templateFuncs()).Parse(val)
+			if err != nil {
+				return nil, fmt.Errorf("error while parsing the template for header %q: %w", key, err)
+			}
+			tmpls = append(tmpls, headerTemplate{key: key, tmpl: tmpl})
+		}
+		if len(static) == 0 {
+			header.Del(key)
+		} else {
+			header[key] = static
+		}
+	}
+	return tmpls, nil
+}
+
 func (d *Destination) Open(ctx context.Context) error {
 	// create client
-	d.client = &http.Client{}
+	var err error
+	d.client, err = d.config.newClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed creating HTTP client: %w", err)
+	}
 
 	// check connection
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, d.config.URL, nil)
+	if !d.config.ValidateConnection {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, d.config.ValidateConnectionMethod, d.config.URL, nil)
 	if err != nil {
-		return fmt.Errorf("error creating HTTP request %q: %w", d.config.URL, err)
+		return fmt.Errorf("error creating HTTP request %q: %w", d.config.redactURL(d.config.URL), d.config.redactError(err))
 	}
 	req.Header = d.header
 	resp, err := d.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("error pinging URL %q: %w", d.config.URL, err)
+		return fmt.Errorf("error pinging URL %q: %w", d.config.redactURL(d.config.URL), d.config.redactError(err))
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 400 {
@@ -104,13 +381,112 @@ func (d *Destination) Open(ctx context.Context) error {
 }
 
 func (d *Destination) Write(ctx context.Context, records []opencdc.Record) (int, error) {
+	if d.config.Concurrency > 1 {
+		return d.writeConcurrently(ctx, records)
+	}
 	for i, rec := range records {
-		err := d.sendRequest(ctx, rec)
+		err := d.writeRecord(ctx, rec)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+// errorCause returns the beginning of the body of a rejected response, with
+// the credentials redacted, so that it can be included in the error.
+func (d *Destination) errorCause(resp *http.Response) string {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes+1))
+	if err != nil {
+		return ""
+	}
+	cause := string(body)
+	if len(body) > maxErrorBodyBytes {
+		cause = string(body[:maxErrorBodyBytes]) + "... (truncated)"
+	}
+	return d.config.redactSecrets(cause)
+}
+
+// writeRecord sends the request for the record. With onError skip, a record
+// rejected by the server is logged and skipped. A record without a payload
+// is handled according to onEmptyPayload.
+func (d *Destination) writeRecord(ctx context.Context, rec opencdc.Record) error {
+	if d.missingPayload(rec) {
+		switch d.config.OnEmptyPayload {
+		case onEmptyPayloadSkip:
+			sdk.Logger(ctx).Warn().
+				Str("position", string(rec.Position)).
+				Msg("skipping record without a payload")
+			return nil
+		case onEmptyPayloadError:
+			return fmt.Errorf("record at position %q has no payload, a %v request requires one", rec.Position, d.config.Method)
+		}
+	}
+
+	err := d.sendRequest(ctx, rec)
+	var statusErr *statusError
+	if err != nil && d.config.OnError == onErrorSkip && errors.As(err, &statusErr) {
+		sdk.Logger(ctx).Warn().
+			Err(err).
+			Str("position", string(rec.Position)).
+			Msg("skipping record rejected by the server")
+		return nil
+	}
+	return err
+}
+
+// missingPayload returns true if the record has no payload, but the request
+// sent for it needs one as its body. DELETE requests and GraphQL mutations
+// don't need a payload.
+func (d *Destination) missingPayload(rec opencdc.Record) bool {
+	return rec.Payload.After == nil && d.config.Method != http.MethodDelete && d.graphQLMutationTmpl == nil
+}
+
+// writeConcurrently sends the records using up to `concurrency` parallel
+// requests. Once a request fails, no more requests are started, but the ones
+// that are in flight are completed. The number of records returned is the
+// number of records written before the first failed record.
+func (d *Destination) writeConcurrently(ctx context.Context, records []opencdc.Record) (int, error) {
+	errs := make([]error, len(records))
+	indices := make(chan int)
+	var failed atomic.Bool
+
+	var wg sync.WaitGroup
+	for range min(d.config.Concurrency, len(records)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = d.writeRecord(ctx, records[i])
+				if errs[i] != nil {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+
+	// records are handed out in order, so all records before a failed one
+	// have been sent
+	sent := 0
+	for sent < len(records) && !failed.Load() && ctx.Err() == nil {
+		select {
+		case indices <- sent:
+			sent++
+		case <-ctx.Done():
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	for i, err := range errs[:sent] {
 		if err != nil {
 			return i, err
 		}
 	}
-	return 0, nil
+	if sent < len(records) {
+		return sent, ctx.Err()
+	}
+	return len(records), nil
 }
 func (d *Destination) getURL(rec opencdc.Record) (string, error) {
 	URL, err := d.EvaluateURL(rec)
@@ -134,7 +510,7 @@ func (d *Destination) EvaluateURL(rec opencdc.Record) (string, error) {
 	}
 	u, err := url.Parse(b.String())
 	if err != nil {
-		return "", fmt.Errorf("error parsing URL: %w", err)
+		return "", fmt.Errorf("error parsing URL: %w", d.config.redactError(err))
 	}
 	q, err := url.ParseQuery(u.RawQuery)
 	if err != nil {
@@ -144,10 +520,36 @@ func (d *Destination) EvaluateURL(rec opencdc.Record) (string, error) {
 	return u.String(), nil
 }
 
+// getHeader returns the request header for the record, which combines the
+// static headers with the evaluated header templates.
+func (d *Destination) getHeader(rec opencdc.Record) (http.Header, error) {
+	header := d.header.Clone()
+	for _, ht := range d.headerTmpls {
+		var b strings.Builder
+		err := ht.tmpl.Execute(&b, rec)
+		if err != nil {
+			return nil, fmt.Errorf("error while evaluating the template for header %q: %w", ht.key, err)
+		}
+		header.Add(ht.key, b.String())
+	}
+	if d.config.KeyHeader != "" && rec.Key != nil {
+		header.Set(d.config.KeyHeader, dataString(rec.Key))
+	}
+	return header, nil
+}
+
 func (d *Destination) sendRequest(ctx context.Context, record opencdc.Record) error {
 	var body io.Reader
-	if record.Payload.After != nil {
-		body = bytes.NewReader(record.Payload.After.Bytes())
+	payload, contentType, err := d.requestBody(record)
+	if err != nil {
+		return err
+	}
+	if payload != nil && !d.config.Streaming {
+		compressed, err := d.compress(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(compressed)
 	}
 	URL, err := d.getURL(record)
 	if err != nil {
@@ -158,23 +560,170 @@ func (d *Destination) sendRequest(ctx context.Context, record opencdc.Record) er
 	if err != nil {
 		return fmt.Errorf("error creating HTTP %s request: %w", d.config.Method, err)
 	}
-	req.Header = d.header
+	req.Header, err = d.getHeader(record)
+	if err != nil {
+		return err
+	}
+	switch {
+	case contentType != "":
+		// determined by the body encoding
+		req.Header.Set("Content-Type", contentType)
+	case req.Header.Get("Content-Type") == "":
+		if ct := d.payloadContentType(record); ct != "" {
+			req.Header.Set("Content-Type", ct)
+		}
+	}
+	if d.config.IdempotencyKey != "" {
+		req.Header.Set(d.config.IdempotencyKey, idempotencyKey(record))
+	}
+	if payload != nil && d.config.Streaming {
+		d.setStreamingBody(req, payload)
+	}
+	if payload != nil && d.config.Compression != "" {
+		req.Header.Set("Content-Encoding", d.config.Compression)
+	}
 
 	// get response
-	resp, err := d.client.Do(req)
+	// with an idempotency key the server discards a request it already
+	// processed, so it can be retried after a network error
+	idempotent := isIdempotent(req) || d.config.IdempotencyKey != ""
+	start := time.Now()
+	resp, err := d.config.doWithRetry(d.client, req, idempotent)
 	if err != nil {
 		return fmt.Errorf("error getting data from URL: %w", err)
 	}
 	defer resp.Body.Close()
+	duration := time.Since(start)
+	sdk.Logger(ctx).Debug().
+		Int("status", resp.StatusCode).
+		Dur("duration", duration).
+		Msg("request completed")
+	return d.handleResponse(ctx, record, resp, duration)
+}
+
+// handleResponse checks the response of the request sent for the record. The
+// duration includes retries.
+func (d *Destination) handleResponse(ctx context.Context, record opencdc.Record, resp *http.Response, duration time.Duration) error {
 	// check if response status is an error code
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("got an unexpected response status of %q", resp.Status)
+	if !d.expectedStatus.contains(resp.StatusCode) {
+		return d.config.newStatusError(resp, d.expectedStatus, d.errorCause(resp))
+	}
+	if d.config.CaptureResponse {
+		d.captureResponse(ctx, record, resp, duration)
+	}
+	if d.graphQLMutationTmpl != nil {
+		respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxGraphQLResponseBytes+1))
+		if err != nil {
+			return fmt.Errorf("error reading GraphQL response: %w", err)
+		}
+		if len(respBody) > maxGraphQLResponseBytes {
+			return fmt.Errorf("GraphQL response exceeds %d bytes", maxGraphQLResponseBytes)
+		}
+		return checkGraphQLErrors(respBody)
 	}
 	return nil
 }
 
+// captureResponse logs the beginning of the response body together with the
+// record's key and position.
+func (d *Destination) captureResponse(ctx context.Context, rec opencdc.Record, resp *http.Response, duration time.Duration) {
+	body := peekBody(resp, int64(d.config.LogMaxBodyBytes))
+
+	var key string
+	if rec.Key != nil {
+		key = string(rec.Key.Bytes())
+	}
+	sdk.Logger(ctx).Info().
+		Str("key", key).
+		Str("position", string(rec.Position)).
+		Int("status", resp.StatusCode).
+		Dur("duration", duration).
+		Str("body", string(body)).
+		Msg("captured HTTP response")
+}
+
+// idempotencyKey returns a key that's stable for the record, derived from its
+// key and position.
+func idempotencyKey(rec opencdc.Record) string {
+	h := sha256.New()
+	if rec.Key != nil {
+		h.Write(rec.Key.Bytes())
+	}
+	// separates the key from the position
+	h.Write([]byte{0})
+	h.Write(rec.Position)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// requestBody returns the body of the request sent for the record, and the
+// content type that needs to be set for it, if it's determined by the body.
+// Structured payloads are encoded with bodyEncoding, see encodePayload. All
+// encodings write the keys of every map in sorted order, with json because
+// opencdc.StructuredData.Bytes uses encoding/json. The same payload always
+// results in the same body, which signatures computed over the body rely on.
+func (d *Destination) requestBody(rec opencdc.Record) ([]byte, string, error) {
+	switch {
+	case d.graphQLMutationTmpl != nil:
+		body, err := d.graphQLBody(rec)
+		return body, "", err
+	case d.config.ContentType == contentTypeMultipart:
+		return d.multipartBody(rec)
+	case d.config.ContentType == contentTypeForm:
+		return d.formBody(rec)
+	case rec.Payload.After != nil:
+		body, err := d.encodePayload(rec.Payload.After)
+		return body, "", err
+	default:
+		return nil, "", nil
+	}
+}
+
+// setStreamingBody sets the request body to a reader without a known length,
+// so that the body is sent using chunked transfer encoding. With compression,
+// the payload is compressed while it's sent, instead of upfront.
+func (d *Destination) setStreamingBody(req *http.Request, payload []byte) {
+	req.GetBody = func() (io.ReadCloser, error) {
+		if d.config.Compression != compressionGzip {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+		pr, pw := io.Pipe()
+		go func() {
+			zw := gzip.NewWriter(pw)
+			_, err := zw.Write(payload)
+			if err == nil {
+				err = zw.Close()
+			}
+			pw.CloseWithError(err)
+		}()
+		return pr, nil
+	}
+	// GetBody doesn't return an error
+	req.Body, _ = req.GetBody()
+	req.ContentLength = -1
+}
+
+// compress compresses the request body using the configured compression.
+func (d *Destination) compress(payload []byte) ([]byte, error) {
+	if d.config.Compression != compressionGzip {
+		return payload, nil
+	}
+
+	var b bytes.Buffer
+	zw := gzip.NewWriter(&b)
+	_, err := zw.Write(payload)
+	if err != nil {
+		return nil, fmt.Errorf("error compressing request body: %w", err)
+	}
+	err = zw.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error compressing request body: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
 func (d *Destination) Teardown(ctx context.Context) error {
 	if d.client != nil {
+		releaseRateLimit(d.client.Transport)
 		d.client.CloseIdleConnections()
 	}
 	return nil
@@ -15,11 +15,20 @@
 package http
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,7 +39,11 @@ import (
 var serverRunning bool
 
 func TestMain(m *testing.M) {
-	runServer()
+	err := runServer()
+	if err != nil {
+		fmt.Printf("Server error: %s\n", err)
+		os.Exit(1)
+	}
 	os.Exit(m.Run())
 }
 
@@ -44,7 +57,7 @@ func TestTeardown_NoOpen(t *testing.T) {
 
 func TestDestination_Post(t *testing.T) {
 	is := is.New(t)
-	runServer()
+	is.NoErr(runServer())
 	url := "http://localhost:8081/resource"
 	ctx := context.Background()
 	dest := NewDestination()
@@ -69,7 +82,7 @@ func TestDestination_Post(t *testing.T) {
 
 func TestDestination_Delete(t *testing.T) {
 	is := is.New(t)
-	runServer()
+	is.NoErr(runServer())
 	url := "http://localhost:8081/resource/1"
 	ctx := context.Background()
 	dest := NewDestination()
@@ -90,7 +103,7 @@ func TestDestination_Delete(t *testing.T) {
 
 func TestDestination_DynamicURL(t *testing.T) {
 	is := is.New(t)
-	runServer()
+	is.NoErr(runServer())
 	url := "http://localhost:8081/resource/{{.Payload.After.id}}"
 	ctx := context.Background()
 	dest := NewDestination()
@@ -115,6 +128,408 @@ func TestDestination_DynamicURL(t *testing.T) {
 	is.True(!ok)
 }
 
+func TestDestination_DeleteWithBody(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var method, contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		method, contentType = r.Method, r.Header.Get("Content-Type")
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":     server.URL + "/items/bulk-delete",
+		"method":  "DELETE",
+		"headers": "Content-Type:application/json",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Payload: opencdc.Change{After: opencdc.RawData(`["1","2","3"]`)},
+	}})
+	is.NoErr(err)
+	is.Equal(method, http.MethodDelete)
+	is.Equal(contentType, "application/json")
+	is.Equal(body, `["1","2","3"]`)
+}
+
+func TestDestination_ContentType(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name    string
+		config  map[string]string
+		payload opencdc.Data
+		want    string
+	}{
+		{name: "structured default", payload: opencdc.StructuredData{"id": 1}, want: "application/json"},
+		{name: "raw json", payload: opencdc.RawData(`{"id":1}`), want: "application/json"},
+		{name: "raw text", payload: opencdc.RawData("hello"), want: "text/plain; charset=utf-8"},
+		{name: "raw binary", payload: opencdc.RawData("\x00\x01\x02"), want: "application/octet-stream"},
+		{
+			name:    "configured",
+			config:  map[string]string{"contentType": "text/plain; charset=iso-8859-1"},
+			payload: opencdc.RawData("hello"),
+			want:    "text/plain; charset=iso-8859-1",
+		},
+		{
+			name: "header override",
+			config: map[string]string{
+				"contentType": "application/xml",
+				"headers":     "Content-Type:application/vnd.api+json",
+			},
+			payload: opencdc.StructuredData{"id": 1},
+			want:    "application/vnd.api+json",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var contentType string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					return
+				}
+				contentType = r.Header.Get("Content-Type")
+			}))
+			t.Cleanup(server.Close)
+
+			cfg := map[string]string{"url": server.URL}
+			for k, v := range tc.config {
+				cfg[k] = v
+			}
+			dest := NewDestination()
+			err := dest.Configure(ctx, cfg)
+			is.NoErr(err)
+			err = dest.Open(ctx)
+			is.NoErr(err)
+
+			_, err = dest.Write(ctx, []opencdc.Record{{
+				Payload: opencdc.Change{After: tc.payload},
+			}})
+			is.NoErr(err)
+			is.Equal(contentType, tc.want)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		is := is.New(t)
+		err := NewDestination().Configure(ctx, map[string]string{
+			"url":         "http://localhost",
+			"contentType": "application/json; charset",
+		})
+		is.True(err != nil)
+	})
+}
+
+func TestDestination_Key(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		key  opencdc.Data
+		want string
+	}{
+		{name: "raw", key: opencdc.RawData("order-1"), want: "order-1"},
+		{name: "structured", key: opencdc.StructuredData{"id": 1}, want: `{"id":1}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var path, keyHeader string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					return
+				}
+				path, keyHeader = r.URL.Path, r.Header.Get("X-Record-Key")
+			}))
+			t.Cleanup(server.Close)
+
+			dest := NewDestination()
+			err := dest.Configure(ctx, map[string]string{
+				"url":       server.URL + "/items/{{ dataString .Key | pathEscape }}",
+				"keyHeader": "X-Record-Key",
+			})
+			is.NoErr(err)
+			err = dest.Open(ctx)
+			is.NoErr(err)
+
+			_, err = dest.Write(ctx, []opencdc.Record{{
+				Key:     tc.key,
+				Payload: opencdc.Change{After: opencdc.RawData("x")},
+			}})
+			is.NoErr(err)
+			is.Equal(path, "/items/"+tc.want)
+			is.Equal(keyHeader, tc.want)
+		})
+	}
+}
+
+func TestDestination_SortedJSONBody(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var bodies []string
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		signatures = append(signatures, r.Header.Get("X-Signature"))
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":     server.URL,
+		"headers": "X-Signature:{{ toJson .Payload.After | sha256sum }}",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	payload := opencdc.StructuredData{
+		"zeta":  1,
+		"alpha": map[string]any{"y": true, "b": "x"},
+		"mid":   []any{map[string]any{"d": 1, "c": 2}},
+	}
+	want := `{"alpha":{"b":"x","y":true},"mid":[{"c":2,"d":1}],"zeta":1}`
+	for range 10 {
+		_, err = dest.Write(ctx, []opencdc.Record{{Payload: opencdc.Change{After: payload}}})
+		is.NoErr(err)
+	}
+
+	sum := sha256.Sum256([]byte(want))
+	is.Equal(len(bodies), 10)
+	for i := range bodies {
+		is.Equal(bodies[i], want)
+		is.Equal(signatures[i], hex.EncodeToString(sum[:]))
+	}
+}
+
+func TestDestination_URLEscape(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	testCases := []struct {
+		url  string
+		id   any
+		want string
+	}{
+		{url: "http://localhost/items/{{ .Payload.After.id | pathEscape }}", id: "a/b", want: "http://localhost/items/a%2Fb"},
+		{url: "http://localhost/items/{{ .Payload.After.id | pathEscape }}", id: float64(1000000), want: "http://localhost/items/1000000"},
+		{url: "http://localhost/items/{{ .Payload.After.id | pathEscape }}", id: "hello world", want: "http://localhost/items/hello%20world"},
+		{url: "http://localhost/items/{{ .Payload.After.id }}", id: "hello world", want: "http://localhost/items/hello%20world"},
+		{url: "http://localhost/items/{{ .Payload.After.id }}?q=1", id: "a/b", want: "http://localhost/items/a/b?q=1"},
+		{url: "http://localhost/items?q={{ .Payload.After.id | queryEscape }}", id: "a&b=c d", want: "http://localhost/items?q=a%26b%3Dc+d"},
+		{url: "http://localhost/items?q={{ .Payload.After.id | queryEscape }}", id: 1.5, want: "http://localhost/items?q=1.5"},
+		{url: "http://localhost/items?q={{ .Payload.After.id }}", id: "a&b", want: "http://localhost/items?b=&q=a"},
+	}
+	for _, tc := range testCases {
+		dest := &Destination{}
+		err := dest.Configure(ctx, map[string]string{"url": tc.url})
+		is.NoErr(err)
+
+		got, err := dest.getURL(opencdc.Record{
+			Payload: opencdc.Change{After: opencdc.StructuredData{"id": tc.id}},
+		})
+		is.NoErr(err)
+		is.Equal(got, tc.want)
+	}
+}
+
+func TestDestination_HeaderTemplates(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			got = r.Header
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":     server.URL,
+		"headers": `Idempotency-Key:{{ .Key.Bytes | toString }},X-Event-Type:{{ index .Metadata "event.type" }},X-Static:value`,
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Key:      opencdc.RawData("key-1"),
+		Metadata: opencdc.Metadata{"event.type": "created"},
+	}})
+	is.NoErr(err)
+	is.Equal(got.Get("Idempotency-Key"), "key-1")
+	is.Equal(got.Get("X-Event-Type"), "created")
+	is.Equal(got.Get("X-Static"), "value")
+}
+
+func TestDestination_GzipCompression(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var encoding, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		encoding = r.Header.Get("Content-Encoding")
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b, _ := io.ReadAll(zr)
+		body = string(b)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":         server.URL,
+		"compression": "gzip",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Payload: opencdc.Change{After: opencdc.RawData(`{"id": "1"}`)},
+	}})
+	is.NoErr(err)
+	is.Equal(encoding, "gzip")
+	is.Equal(body, `{"id": "1"}`)
+}
+
+func TestDestination_Streaming(t *testing.T) {
+	ctx := context.Background()
+	payload := strings.Repeat("0123456789", 10000)
+
+	for _, compression := range []string{"", "gzip"} {
+		t.Run("compression="+compression, func(t *testing.T) {
+			is := is.New(t)
+
+			var attempts int
+			var transferEncoding []string
+			var contentLength int64
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					return
+				}
+				attempts++
+				transferEncoding, contentLength = r.TransferEncoding, r.ContentLength
+				var reader io.Reader = r.Body
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					zr, err := gzip.NewReader(r.Body)
+					is.NoErr(err)
+					reader = zr
+				}
+				b, _ := io.ReadAll(reader)
+				body = string(b)
+				if attempts == 1 {
+					// the body is sent again when the request is retried
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			dest := NewDestination()
+			err := dest.Configure(ctx, map[string]string{
+				"url":           server.URL,
+				"streaming":     "true",
+				"compression":   compression,
+				"retry.backoff": "1ms",
+			})
+			is.NoErr(err)
+			err = dest.Open(ctx)
+			is.NoErr(err)
+
+			_, err = dest.Write(ctx, []opencdc.Record{{
+				Payload: opencdc.Change{After: opencdc.RawData(payload)},
+			}})
+			is.NoErr(err)
+			is.Equal(attempts, 2)
+			is.Equal(transferEncoding, []string{"chunked"})
+			is.Equal(contentLength, int64(-1))
+			is.Equal(body, payload)
+		})
+	}
+}
+
+func TestDestination_Concurrency(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	received := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		received[string(body)] = true
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if string(body) == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":         server.URL,
+		"concurrency": "4",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	records := make([]opencdc.Record, 8)
+	for i := range records {
+		records[i].Payload.After = opencdc.RawData(fmt.Sprintf("record-%d", i))
+	}
+	n, err := dest.Write(ctx, records)
+	is.NoErr(err)
+	is.Equal(n, len(records))
+	is.Equal(len(received), len(records))
+	is.Equal(maxInFlight, 4)
+
+	// the count stops at the first failed record
+	records[2].Payload.After = opencdc.RawData("fail")
+	n, err = dest.Write(ctx, records)
+	is.True(err != nil)
+	is.Equal(n, 2)
+}
+
 // resource represents a dummy resource
 type resource struct {
 	ID   string `json:"id"`
@@ -127,9 +542,10 @@ var resources = map[string]resource{
 	"3": {ID: "3", Name: "Item 3"},
 }
 
-func runServer() {
+// runServer starts the test server, it returns once the server is reachable.
+func runServer() error {
 	if serverRunning {
-		return
+		return nil
 	}
 	serverRunning = true
 	address := ":8081"
@@ -143,12 +559,18 @@ func runServer() {
 		WriteTimeout: 10 * time.Second, // Set your desired write timeout
 	}
 
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
 	go func() {
-		err := server.ListenAndServe()
+		err := server.Serve(listener)
 		if err != nil {
 			fmt.Printf("Server error: %s\n", err)
 		}
 	}()
+	return nil
 }
 
 // handleResource handles POST requests to create a new resource
@@ -206,3 +628,180 @@ func handleSingleResource(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
+
+func TestDestination_ValidateConnection(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{"url": server.URL})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.True(err != nil)
+
+	// write-only endpoints can be probed with another method
+	methods = nil
+	dest = NewDestination()
+	err = dest.Configure(ctx, map[string]string{
+		"url":                       server.URL,
+		"validateConnection.method": "OPTIONS",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+	is.Equal(methods, []string{http.MethodOptions})
+
+	methods = nil
+	dest = NewDestination()
+	err = dest.Configure(ctx, map[string]string{
+		"url":                server.URL,
+		"validateConnection": "false",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+	is.Equal(len(methods), 0) // no request was sent
+
+	_, err = dest.Write(ctx, []opencdc.Record{{Payload: opencdc.Change{After: opencdc.RawData("data")}}})
+	is.NoErr(err)
+	is.Equal(methods, []string{http.MethodPost})
+}
+
+func TestDestination_OnErrorSkip(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+		if string(body) == "invalid" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	records := []opencdc.Record{
+		{Payload: opencdc.Change{After: opencdc.RawData("valid-1")}},
+		{Payload: opencdc.Change{After: opencdc.RawData("invalid")}},
+		{Payload: opencdc.Change{After: opencdc.RawData("valid-2")}},
+	}
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{"url": server.URL})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+	n, err := dest.Write(ctx, records)
+	is.True(err != nil)
+	is.Equal(n, 1)
+
+	received = nil
+	dest = NewDestination()
+	err = dest.Configure(ctx, map[string]string{
+		"url":     server.URL,
+		"onError": "skip",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+	n, err = dest.Write(ctx, records)
+	is.NoErr(err)
+	is.Equal(n, 3)
+	is.Equal(received, []string{"valid-1", "invalid", "valid-2"})
+}
+
+func TestDestination_ErrorResponseBody(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var respBody atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, respBody.Load())
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":                server.URL,
+		"auth.apiKey.value":  "secret-key",
+		"auth.apiKey.header": "X-API-Key",
+		"validateConnection": "false",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	respBody.Store(`{"error":"invalid api key secret-key"}`)
+	_, err = dest.Write(ctx, []opencdc.Record{{}})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), `cause={"error":"invalid api key ***"}`))
+
+	respBody.Store(strings.Repeat("a", maxErrorBodyBytes+1))
+	_, err = dest.Write(ctx, []opencdc.Record{{}})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "cause="+strings.Repeat("a", maxErrorBodyBytes)+"... (truncated)"))
+}
+
+func TestDestination_OnEmptyPayload(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			requests.Add(1)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		onEmptyPayload string
+		method         string
+		wantRequests   int32
+		wantErr        bool
+	}{
+		{onEmptyPayload: "send", method: "POST", wantRequests: 2},
+		{onEmptyPayload: "skip", method: "POST", wantRequests: 1},
+		{onEmptyPayload: "error", method: "PUT", wantRequests: 0, wantErr: true},
+		{onEmptyPayload: "error", method: "DELETE", wantRequests: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.onEmptyPayload+"/"+tc.method, func(t *testing.T) {
+			is := is.New(t)
+			requests.Store(0)
+
+			dest := NewDestination()
+			err := dest.Configure(ctx, map[string]string{
+				"url":            server.URL,
+				"method":         tc.method,
+				"onEmptyPayload": tc.onEmptyPayload,
+			})
+			is.NoErr(err)
+			err = dest.Open(ctx)
+			is.NoErr(err)
+
+			_, err = dest.Write(ctx, []opencdc.Record{
+				{Position: opencdc.Position("1")},
+				{Position: opencdc.Position("2"), Payload: opencdc.Change{After: opencdc.RawData("data")}},
+			})
+			is.Equal(err != nil, tc.wantErr)
+			is.Equal(requests.Load(), tc.wantRequests)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const contentTypeForm = "form"
+
+// formBody encodes the record payload as an application/x-www-form-urlencoded
+// body. Each field of structured data is sent as a form field, while raw data
+// is sent as a single field named after form.rawField.
+func (d *Destination) formBody(rec opencdc.Record) ([]byte, string, error) {
+	values := url.Values{}
+	switch payload := rec.Payload.After.(type) {
+	case nil:
+	case opencdc.StructuredData:
+		for key, val := range payload {
+			vals, err := formValues(val)
+			if err != nil {
+				return nil, "", fmt.Errorf("error encoding form field %q: %w", key, err)
+			}
+			values[key] = vals
+		}
+	default:
+		values.Set(d.config.FormRawField, string(payload.Bytes()))
+	}
+	return []byte(values.Encode()), "application/x-www-form-urlencoded", nil
+}
+
+// formValues returns the form values of a structured data field. Scalars are
+// sent as a single value, with numbers formatted with their exact value, the
+// elements of a list as repeated values and any other value is encoded as JSON.
+func formValues(val any) ([]string, error) {
+	switch v := val.(type) {
+	case nil:
+		return []string{""}, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		vals := make([]string, 0, len(v))
+		for _, elem := range v {
+			elemVals, err := formValues(elem)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, elemVals...)
+		}
+		return vals, nil
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(b)}, nil
+	default:
+		return []string{formatValue(v)}, nil
+	}
+}
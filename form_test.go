@@ -0,0 +1,87 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestDestination_Form(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var got []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		err := r.ParseForm()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got = append(got, r.PostForm)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":         server.URL,
+		"contentType": "form",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Payload: opencdc.Change{After: opencdc.StructuredData{
+			"text":     "hello world",
+			"count":    2,
+			"amount":   float64(1000000),
+			"price":    1.25,
+			"total":    json.Number("12345678901234567890"),
+			"tags":     []any{"a", "b"},
+			"user":     map[string]any{"id": 1},
+			"optional": nil,
+		}},
+	}, {
+		Payload: opencdc.Change{After: opencdc.RawData(`{"text":"hello"}`)},
+	}})
+	is.NoErr(err)
+	is.Equal(got, []url.Values{{
+		"text":     {"hello world"},
+		"count":    {"2"},
+		"amount":   {"1000000"},
+		"price":    {"1.25"},
+		"total":    {"12345678901234567890"},
+		"tags":     {"a", "b"},
+		"user":     {`{"id":1}`},
+		"optional": {""},
+	}, {
+		"payload": {`{"text":"hello"}`},
+	}})
+}
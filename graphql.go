@@ -0,0 +1,181 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// graphQLRequest is the body of a GraphQL request sent over HTTP.
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLError is an error returned in the `errors` array of a GraphQL response.
+type graphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// validateGraphQL validates the GraphQL options of the source.
+func (s *SourceConfig) validateGraphQL() error {
+	if s.GraphQLQuery == "" {
+		if s.GraphQLVariables != "" || s.GraphQLDataPath != "" {
+			return errors.New("graphql.variables and graphql.dataPath require graphql.query to be set")
+		}
+		return nil
+	}
+	if s.GraphQLDataPath == "" {
+		return errors.New("graphql.dataPath is required with graphql.query")
+	}
+	if s.RequestBody != "" || s.hasRecordsPath() || s.ParseResponseScript != "" ||
+		s.ResponseFormat != responseFormatRaw {
+		return errors.New("graphql.query can't be used with requestBody, response.recordsPath(s), script.parseResponse or responseFormat")
+	}
+	return nil
+}
+
+// initGraphQL sets up the source to send the GraphQL query. If the variables
+// are a template, the body is created before each request, otherwise it's
+// created once and sent as the request body.
+func (s *Source) initGraphQL() error {
+	s.config.Method = http.MethodPost
+	if s.header.Get("Content-Type") == "" {
+		s.header.Set("Content-Type", "application/json")
+	}
+	if !strings.Contains(s.config.GraphQLVariables, "{{") {
+		var err error
+		s.config.RequestBody, err = newGraphQLBody(s.config.GraphQLQuery, s.config.GraphQLVariables)
+		return err
+	}
+	tmpl, err := template.New("graphql.variables").Funcs(templateFuncs()).Parse(s.config.GraphQLVariables)
+	if err != nil {
+		return fmt.Errorf("error parsing graphql.variables template: %w", err)
+	}
+	s.graphQLVariablesTmpl = tmpl
+	return nil
+}
+
+// requestBody returns the body of the request, which is the configured body,
+// or the GraphQL query with the evaluated variables template.
+func (s *Source) requestBody() (string, error) {
+	if s.graphQLVariablesTmpl == nil {
+		return s.config.RequestBody, nil
+	}
+	var variables strings.Builder
+	err := s.graphQLVariablesTmpl.Execute(&variables, s.templateData())
+	if err != nil {
+		return "", fmt.Errorf("error while evaluating the graphql.variables template: %w", err)
+	}
+	return newGraphQLBody(s.config.GraphQLQuery, variables.String())
+}
+
+// newGraphQLBody encodes the query and the variables, a JSON object, into the
+// body of a GraphQL request.
+func newGraphQLBody(query string, variables string) (string, error) {
+	req := graphQLRequest{Query: query}
+	if variables != "" {
+		err := json.Unmarshal([]byte(variables), &req.Variables)
+		if err != nil {
+			return "", fmt.Errorf("graphql.variables needs to be a JSON object: %w", err)
+		}
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed encoding GraphQL request: %w", err)
+	}
+	return string(body), nil
+}
+
+// graphQLBody returns the body of the GraphQL request sent by the destination
+// for the record.
+func (d *Destination) graphQLBody(rec opencdc.Record) ([]byte, error) {
+	var mutation, variables bytes.Buffer
+	err := d.graphQLMutationTmpl.Execute(&mutation, rec)
+	if err != nil {
+		return nil, fmt.Errorf("error while evaluating the graphql.mutation template: %w", err)
+	}
+	err = d.graphQLVariablesTmpl.Execute(&variables, rec)
+	if err != nil {
+		return nil, fmt.Errorf("error while evaluating the graphql.variables template: %w", err)
+	}
+	body, err := newGraphQLBody(mutation.String(), variables.String())
+	if err != nil {
+		return nil, err
+	}
+	return []byte(body), nil
+}
+
+// maxGraphQLResponseBytes is the maximum size of a GraphQL response to a
+// mutation sent by the destination. Only the errors of the response are used.
+const maxGraphQLResponseBytes = 10 << 20
+
+// checkGraphQLErrors returns an error if the GraphQL response contains errors.
+// GraphQL servers usually report errors with the status 200 OK.
+func checkGraphQLErrors(body []byte) error {
+	var resp struct {
+		Errors []graphQLError `json:"errors"`
+	}
+	err := json.Unmarshal(body, &resp)
+	if err != nil {
+		return fmt.Errorf("failed decoding GraphQL response: %w", err)
+	}
+	if len(resp.Errors) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, len(resp.Errors))
+	for i, e := range resp.Errors {
+		msgs[i] = e.Message
+		if len(e.Path) > 0 {
+			msgs[i] = fmt.Sprintf("%v (path: %v)", e.Message, e.Path)
+		}
+	}
+	return fmt.Errorf("GraphQL response contains errors: %v", strings.Join(msgs, "; "))
+}
+
+// graphQLResponseParser extracts the records from the data of a GraphQL
+// response, after making sure the response doesn't contain any errors.
+type graphQLResponseParser struct {
+	records *jsonResponseParser
+}
+
+func newGraphQLResponseParser(cfg SourceConfig, operations *operationMapper) (*graphQLResponseParser, error) {
+	// the records are located within the data of the response
+	cfg.ResponseRecordsPath = "data." + strings.TrimPrefix(strings.TrimPrefix(cfg.GraphQLDataPath, "$"), ".")
+	records, err := newJSONResponseParser(cfg, operations)
+	if err != nil {
+		return nil, err
+	}
+	return &graphQLResponseParser{records: records}, nil
+}
+
+func (p *graphQLResponseParser) parse(ctx context.Context, responseBytes []byte, meta responseMetadata) (*Response, error) {
+	err := checkGraphQLErrors(responseBytes)
+	if err != nil {
+		return nil, err
+	}
+	return p.records.parse(ctx, responseBytes, meta)
+}
@@ -0,0 +1,220 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestSource_GraphQL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var got graphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		fmt.Fprint(w, `{"data": {"users": {"nodes": [{"id": "1"}, {"id": "2"}], "pageInfo": {"endCursor": "c2"}}}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                 server.URL,
+		"graphql.query":       "query($first: Int) { users(first: $first) { nodes { id } pageInfo { endCursor } } }",
+		"graphql.variables":   `{"first": 2}`,
+		"graphql.dataPath":    "users.nodes",
+		"response.keyPath":    "id",
+		"response.cursorPath": "data.users.pageInfo.endCursor",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"1", "2"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Key, opencdc.RawData(want))
+		is.Equal(rec.Payload.After, opencdc.StructuredData{"id": want})
+	}
+	is.Equal(got.Query, "query($first: Int) { users(first: $first) { nodes { id } pageInfo { endCursor } } }")
+	is.Equal(got.Variables, map[string]any{"first": float64(2)})
+}
+
+func TestSource_GraphQLVariablesTemplate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var since []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		var req graphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		since = append(since, req.Variables["since"])
+		n := len(since)
+		fmt.Fprintf(w, `{"data": {"events": {"nodes": [{"id": "%d"}], "cursor": "c%d"}}}`, n, n)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                 server.URL,
+		"pollingPeriod":       "10ms",
+		"graphql.query":       "query($since: String) { events(since: $since) { nodes { id } cursor } }",
+		"graphql.variables":   `{"since": {{ .cursor | default "" | toJson }}}`,
+		"graphql.dataPath":    "events.nodes",
+		"response.keyPath":    "id",
+		"response.cursorPath": "data.events.cursor",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"1", "2"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Key, opencdc.RawData(want))
+	}
+	is.Equal(since[:2], []any{"", "c1"})
+}
+
+func TestSource_GraphQLErrors(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": null, "errors": [{"message": "field \"foo\" not found", "path": ["users", 0]}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"graphql.query":    "{ users { foo } }",
+		"graphql.dataPath": "users",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), `field "foo" not found (path: [users 0])`))
+}
+
+func TestSourceConfig_GraphQLValidation(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []map[string]string{
+		{"graphql.query": "{ users { id } }"},
+		{"graphql.query": "{ users { id } }", "graphql.dataPath": "users", "requestBody": "{}"},
+		{"graphql.query": "{ users { id } }", "graphql.dataPath": "users", "graphql.variables": "[]"},
+		{"graphql.query": "{ users { id } }", "graphql.dataPath": "users", "graphql.variables": "{{ .cursor"},
+		{"graphql.dataPath": "users"},
+	}
+	for _, cfg := range testCases {
+		is := is.New(t)
+		cfg["url"] = "http://localhost"
+		err := NewSource().Configure(ctx, cfg)
+		is.True(err != nil)
+	}
+}
+
+func TestDestination_GraphQL(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var got []graphQLRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		var req graphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		got = append(got, req)
+		if req.Variables["input"].(map[string]any)["name"] == "invalid" {
+			// errors are reported with the status 200
+			fmt.Fprint(w, `{"data": null, "errors": [{"message": "name is invalid"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": {"createUser": {"id": "1"}}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":               server.URL,
+		"graphql.mutation":  `mutation($input: UserInput!) { {{ .Metadata.operation }}(input: $input) { id } }`,
+		"graphql.variables": `{"input": {{ toJson .Payload.After }}}`,
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	n, err := dest.Write(ctx, []opencdc.Record{{
+		Metadata: opencdc.Metadata{"operation": "createUser"},
+		Payload:  opencdc.Change{After: opencdc.StructuredData{"name": "jane"}},
+	}, {
+		Metadata: opencdc.Metadata{"operation": "createUser"},
+		Payload:  opencdc.Change{After: opencdc.StructuredData{"name": "invalid"}},
+	}})
+	is.Equal(n, 1)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "name is invalid"))
+
+	is.Equal(len(got), 2)
+	is.Equal(got[0].Query, "mutation($input: UserInput!) { createUser(input: $input) { id } }")
+	is.Equal(got[0].Variables, map[string]any{"input": map[string]any{"name": "jane"}})
+}
+
+func TestDestination_GraphQLResponseTooLarge(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat(" ", maxGraphQLResponseBytes+1))
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"graphql.mutation": `mutation { createUser { id } }`,
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{Payload: opencdc.Change{After: opencdc.RawData("{}")}}})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "GraphQL response exceeds"))
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// http2Transport sends all requests using HTTP/2. Requests to https URLs
+// negotiate HTTP/2 during the TLS handshake, while requests to http URLs use
+// HTTP/2 over cleartext (h2c) with prior knowledge.
+type http2Transport struct {
+	tls *http2.Transport
+	h2c *http2.Transport
+}
+
+// newHTTP2Transport creates the transport used by the HTTP client when http2
+// is enabled.
+func (s *Config) newHTTP2Transport() (*http2Transport, error) {
+	tlsCfg, err := s.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// HTTP/2 multiplexes requests over a single connection per host, so only
+	// the idle timeout applies
+	return &http2Transport{
+		tls: &http2.Transport{
+			TLSClientConfig: tlsCfg,
+			IdleConnTimeout: s.TransportIdleConnTimeout,
+		},
+		h2c: &http2.Transport{
+			AllowHTTP:       true,
+			IdleConnTimeout: s.TransportIdleConnTimeout,
+			// connections to http URLs are "TLS" connections as well, the
+			// dialer makes them plain TCP connections
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}, nil
+}
+
+func (t *http2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
+
+func (t *http2Transport) CloseIdleConnections() {
+	t.tls.CloseIdleConnections()
+	t.h2c.CloseIdleConnections()
+}
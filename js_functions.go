@@ -16,12 +16,25 @@ package http
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/dop251/goja"
+	"github.com/dop251/goja/ast"
+	"github.com/dop251/goja/parser"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/dop251/goja_nodejs/url"
 	"github.com/rs/zerolog"
@@ -34,11 +47,22 @@ var (
 
 type Request struct {
 	URL string
+	// Method overrides the configured method of the request, if not empty.
+	Method string
+	// Body is sent as the request body, if not empty.
+	Body string
+	// Headers are added to the configured headers of the request, replacing
+	// configured headers with the same name.
+	Headers map[string]string
 }
 
 type Response struct {
 	CustomData map[string]any
 	Records    []*jsRecord
+	// HasMore signals that there's another page, which is requested right
+	// away instead of waiting for the next poll. It's only used when no
+	// pagination mode is configured.
+	HasMore bool
 }
 
 // jsRecord is an intermediary representation of opencdc.Record that is passed to
@@ -61,8 +85,24 @@ type jsPayload struct {
 type gojaContext struct {
 	runtime *goja.Runtime
 	fn      goja.Callable
+	fnName  string
+	// timeout is the maximum time a call of the function can take, zero means
+	// no timeout
+	timeout time.Duration
+	// client is used to send the requests of the httpRequest helper, it's set
+	// once the connector is opened
+	client *http.Client
+	// maxBodyBytes is the maximum size of a response body read by the
+	// httpRequest helper, it's set together with client
+	maxBodyBytes int64
+	// cfg is used to redact the URLs in the errors of the httpRequest helper,
+	// it's set together with client
+	cfg *Config
 }
 
+// errScriptTimeout is used to interrupt a script that exceeded its timeout.
+var errScriptTimeout = errors.New("script timeout")
+
 func (c gojaContext) addLogger(logger *zerolog.Logger) error {
 	if err := c.runtime.Set("logger", logger); err != nil {
 		return fmt.Errorf("failed to set logger: %w", err)
@@ -71,18 +111,149 @@ func (c gojaContext) addLogger(logger *zerolog.Logger) error {
 	return nil
 }
 
-func newGojaContext(ctx context.Context, srcPath, fnName string) (*gojaContext, error) {
+// addSleep adds the sleep(ms) helper, which pauses the script for the given
+// number of milliseconds. If ctx is done while sleeping, the script is
+// interrupted, so that it can't keep running after the context is done.
+func (c gojaContext) addSleep(ctx context.Context) error {
+	sleep := func(ms int64) {
+		timer := time.NewTimer(time.Duration(ms) * time.Millisecond)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			c.runtime.Interrupt(context.Cause(ctx))
+		case <-timer.C:
+		}
+	}
+	if err := c.runtime.Set("sleep", sleep); err != nil {
+		return fmt.Errorf("failed to set sleep: %w", err)
+	}
+
+	return nil
+}
+
+// addHTTPRequest adds the httpRequest(opts) and httpGet(url, headers) helpers,
+// which send a request using the connector's HTTP client, without the OAuth2
+// token, and return an object with the response's status, headers (multiple
+// values are comma separated) and body. The options of httpRequest are url,
+// method (GET by default), headers and body. A failed request, or a response
+// body larger than maxResponseBytes, throws an exception.
+func (c gojaContext) addHTTPRequest(ctx context.Context) error {
+	httpRequest := func(opts map[string]any) (map[string]any, error) {
+		if c.client == nil {
+			return nil, errors.New("httpRequest can't be used before the connector is opened")
+		}
+
+		reqURL, _ := opts["url"].(string)
+		method, _ := opts["method"].(string)
+		if method == "" {
+			method = http.MethodGet
+		}
+		var body io.Reader
+		if b, ok := opts["body"].(string); ok && b != "" {
+			body = strings.NewReader(b)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+		if err != nil {
+			return nil, fmt.Errorf("error creating HTTP request: %w", c.cfg.redactError(err))
+		}
+		if headers, ok := opts["headers"].(map[string]any); ok {
+			for key, val := range headers {
+				req.Header.Set(key, fmt.Sprint(val))
+			}
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error sending HTTP request: %w", c.cfg.redactError(err))
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBodyBytes+1))
+		if err != nil {
+			return nil, fmt.Errorf("error reading HTTP response: %w", err)
+		}
+		if int64(len(respBody)) > c.maxBodyBytes {
+			return nil, fmt.Errorf("httpRequest to %v: %w (%d bytes)", c.cfg.redactURL(reqURL), errResponseTooLarge, c.maxBodyBytes)
+		}
+
+		respHeaders := make(map[string]string, len(resp.Header))
+		for key, val := range resp.Header {
+			respHeaders[key] = strings.Join(val, ",")
+		}
+		return map[string]any{
+			"status":  resp.StatusCode,
+			"headers": respHeaders,
+			"body":    string(respBody),
+		}, nil
+	}
+	httpGet := func(reqURL string, headers map[string]any) (map[string]any, error) {
+		return httpRequest(map[string]any{"url": reqURL, "headers": headers})
+	}
+
+	if err := c.runtime.Set("httpRequest", httpRequest); err != nil {
+		return fmt.Errorf("failed to set httpRequest: %w", err)
+	}
+	if err := c.runtime.Set("httpGet", httpGet); err != nil {
+		return fmt.Errorf("failed to set httpGet: %w", err)
+	}
+
+	return nil
+}
+
+// call calls the function with the arguments, after binding the helpers that
+// depend on the context to ctx. The script is interrupted when ctx is done or
+// the call exceeds the timeout, since that's the only way to stop a script that
+// is stuck in a loop.
+func (c gojaContext) call(ctx context.Context, args ...any) (goja.Value, error) {
+	// the previous call might have been interrupted
+	c.runtime.ClearInterrupt()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, c.timeout, errScriptTimeout)
+		defer cancel()
+	}
+	stop := context.AfterFunc(ctx, func() {
+		c.runtime.Interrupt(context.Cause(ctx))
+	})
+	defer stop()
+
+	err := c.addLogger(sdk.Logger(ctx))
+	if err != nil {
+		return nil, err
+	}
+	err = c.addSleep(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = c.addHTTPRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jsArgs := make([]goja.Value, len(args))
+	for i, arg := range args {
+		jsArgs[i] = c.runtime.ToValue(arg)
+	}
+
+	result, err := c.fn(goja.Undefined(), jsArgs...)
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) && interrupted.Value() == errScriptTimeout {
+		return nil, fmt.Errorf("%v didn't finish within script.timeout (%v)", c.fnName, c.timeout)
+	}
+	return result, err
+}
+
+func newGojaContext(ctx context.Context, script, fnName string, timeout time.Duration) (*gojaContext, error) {
 	runtime, err := newRuntime()
 	if err != nil {
 		return nil, fmt.Errorf("failed initializing JS runtime: %w", err)
 	}
 
-	src, err := os.ReadFile(srcPath)
+	src, err := readScript(script)
 	if err != nil {
-		return nil, fmt.Errorf("failed reading file %v: %w", srcPath, err)
+		return nil, err
 	}
 
-	fn, err := newFunction(runtime, string(src), fnName)
+	fn, err := newFunction(runtime, src, fnName)
 	if err != nil {
 		return nil, fmt.Errorf("failed initializing function %q: %w", fnName, err)
 	}
@@ -90,16 +261,100 @@ func newGojaContext(ctx context.Context, srcPath, fnName string) (*gojaContext,
 	return &gojaContext{
 		runtime: runtime,
 		fn:      fn,
+		fnName:  fnName,
+		timeout: timeout,
 	}, nil
 }
 
+// inlineScriptRegex matches inline source code, which contains a function
+// declaration, as opposed to a path to a file.
+var inlineScriptRegex = regexp.MustCompile(`\bfunction\b[^{]*\{`)
+
+// readScript returns the value itself if it's inline source code, otherwise it
+// treats the value as a path and returns the file contents.
+func readScript(val string) (string, error) {
+	if inlineScriptRegex.MatchString(val) {
+		return val, nil
+	}
+	src, err := os.ReadFile(val)
+	if err != nil {
+		return "", fmt.Errorf("failed reading file %v: %w", val, err)
+	}
+	return string(src), nil
+}
+
+// validateScript checks that the script can be read and compiled, and that it
+// declares the function fnName at the top level, either as a function
+// declaration, a variable or an assignment. The script isn't run, so it can't
+// have any side effects.
+func validateScript(script, fnName string) error {
+	src, err := readScript(script)
+	if err != nil {
+		return err
+	}
+	name := "inline script"
+	if !inlineScriptRegex.MatchString(script) {
+		name = script
+	}
+
+	prg, err := parser.ParseFile(nil, name, src, 0)
+	if err != nil {
+		return fmt.Errorf("failed to compile script: %w", err)
+	}
+	// catches errors the parser doesn't report, e.g. invalid assignments
+	_, err = goja.CompileAST(prg, false)
+	if err != nil {
+		return fmt.Errorf("failed to compile script: %w", err)
+	}
+	if !declaresFunction(prg, fnName) {
+		return fmt.Errorf("%v doesn't declare the function %q", name, fnName)
+	}
+	return nil
+}
+
+// declaresFunction returns true if a top-level statement of the program
+// declares or assigns the name.
+func declaresFunction(prg *ast.Program, name string) bool {
+	declares := func(bindings []*ast.Binding) bool {
+		return slices.ContainsFunc(bindings, func(b *ast.Binding) bool {
+			id, ok := b.Target.(*ast.Identifier)
+			return ok && id.Name.String() == name
+		})
+	}
+	for _, stmt := range prg.Body {
+		switch stmt := stmt.(type) {
+		case *ast.FunctionDeclaration:
+			if stmt.Function.Name != nil && stmt.Function.Name.Name.String() == name {
+				return true
+			}
+		case *ast.VariableStatement:
+			if declares(stmt.List) {
+				return true
+			}
+		case *ast.LexicalDeclaration:
+			if declares(stmt.List) {
+				return true
+			}
+		case *ast.ExpressionStatement:
+			assign, ok := stmt.Expression.(*ast.AssignExpression)
+			if !ok {
+				continue
+			}
+			if id, ok := assign.Left.(*ast.Identifier); ok && id.Name.String() == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type jsRequestBuilder struct {
 	gojaCtx *gojaContext
 	cfg     map[string]string
 }
 
-func newJSRequestBuilder(ctx context.Context, cfg map[string]string, srcPath string) (*jsRequestBuilder, error) {
-	gojaCtx, err := newGojaContext(ctx, srcPath, getRequestDataFn)
+func newJSRequestBuilder(ctx context.Context, cfg map[string]string, script string, timeout time.Duration) (*jsRequestBuilder, error) {
+	gojaCtx, err := newGojaContext(ctx, script, getRequestDataFn, timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -112,17 +367,12 @@ func (r *jsRequestBuilder) build(
 	previousResponseData map[string]any,
 	position opencdc.Position,
 ) (*Request, error) {
-	err := r.gojaCtx.addLogger(sdk.Logger(ctx))
-	if err != nil {
-		return nil, err
+	if previousResponseData == nil {
+		// scripts can access fields without checking for undefined first
+		previousResponseData = map[string]any{}
 	}
 
-	result, err := r.gojaCtx.fn(
-		goja.Undefined(),
-		r.gojaCtx.runtime.ToValue(r.cfg),
-		r.gojaCtx.runtime.ToValue(previousResponseData),
-		r.gojaCtx.runtime.ToValue(position),
-	)
+	result, err := r.gojaCtx.call(ctx, r.cfg, previousResponseData, position)
 	if err != nil {
 		return nil, err
 	}
@@ -139,13 +389,23 @@ type jsResponseParser struct {
 	gojaCtx *gojaContext
 }
 
-func (r *jsResponseParser) parse(ctx context.Context, responseBytes []byte) (*Response, error) {
-	err := r.gojaCtx.addLogger(sdk.Logger(ctx))
-	if err != nil {
-		return nil, err
+func (r *jsResponseParser) parse(ctx context.Context, responseBytes []byte, meta responseMetadata) (*Response, error) {
+	headers := make(map[string]string, len(meta.Header))
+	for key, val := range meta.Header {
+		headers[key] = strings.Join(val, ",")
+	}
+	jsMeta := map[string]any{
+		"statusCode": meta.StatusCode,
+		"headers":    headers,
+	}
+	if meta.Request != nil {
+		jsMeta["request"] = map[string]any{
+			"url":  meta.Request.URL,
+			"body": meta.Request.Body,
+		}
 	}
 
-	result, err := r.gojaCtx.fn(goja.Undefined(), r.gojaCtx.runtime.ToValue(responseBytes))
+	result, err := r.gojaCtx.call(ctx, responseBytes, jsMeta)
 	if err != nil {
 		return nil, err
 	}
@@ -158,8 +418,8 @@ func (r *jsResponseParser) parse(ctx context.Context, responseBytes []byte) (*Re
 	return rd, nil
 }
 
-func newJSResponseParser(ctx context.Context, srcPath string) (*jsResponseParser, error) {
-	gojaCtx, err := newGojaContext(ctx, srcPath, parseResponseFn)
+func newJSResponseParser(ctx context.Context, script string, timeout time.Duration) (*jsResponseParser, error) {
+	gojaCtx, err := newGojaContext(ctx, script, parseResponseFn, timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +438,10 @@ func newRuntime() (*goja.Runtime, error) {
 		"StructuredData": newStructuredData(rt),
 		"Request":        newRequestData(rt),
 		"Response":       newResponseData(rt),
+		"sha256":         jsSHA256,
+		"hmacSHA256":     jsHMACSHA256,
+		"base64Encode":   jsBase64Encode,
+		"base64Decode":   jsBase64Decode,
 	}
 
 	for name, helper := range runtimeHelpers {
@@ -189,6 +453,33 @@ func newRuntime() (*goja.Runtime, error) {
 	return rt, nil
 }
 
+// jsSHA256 returns the hex encoded SHA-256 hash of the data.
+func jsSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+// jsHMACSHA256 returns the hex encoded HMAC-SHA256 of the data.
+func jsHMACSHA256(key, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// jsBase64Encode returns the standard base64 encoding of the data.
+func jsBase64Encode(data string) string {
+	return base64.StdEncoding.EncodeToString([]byte(data))
+}
+
+// jsBase64Decode decodes standard base64 encoded data.
+func jsBase64Decode(data string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", fmt.Errorf("failed decoding base64: %w", err)
+	}
+	return string(decoded), nil
+}
+
 func newFunction(runtime *goja.Runtime, src string, fnName string) (goja.Callable, error) {
 	if src == "" {
 		return nil, nil
@@ -254,7 +545,11 @@ func newRecord(runtime *goja.Runtime) func(goja.ConstructorCall) *goja.Object {
 
 func newRequestData(runtime *goja.Runtime) func(goja.ConstructorCall) *goja.Object {
 	return func(call goja.ConstructorCall) *goja.Object {
-		r := Request{}
+		r := Request{
+			// Requests are always initialized with headers, so that scripts
+			// can add headers without initializing the map first.
+			Headers: make(map[string]string),
+		}
 		// We need to return a pointer to make the returned object mutable.
 		return runtime.ToValue(&r).ToObject(runtime)
 	}
@@ -16,7 +16,10 @@ package http
 
 import (
 	"context"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/conduitio/conduit-commons/opencdc"
 	"github.com/google/go-cmp/cmp"
@@ -34,6 +37,7 @@ func TestSourceExtension_GetRequestData(t *testing.T) {
 			"url": "http://example.com",
 		},
 		"./test/get_request_data.js",
+		0,
 	)
 	is.NoErr(err)
 
@@ -48,11 +52,202 @@ func TestSourceExtension_GetRequestData(t *testing.T) {
 	is.Equal("http://example.com/?pageToken=abc&pageSize=2", data.URL)
 }
 
+func TestSourceExtension_GetRequestDataBody(t *testing.T) {
+	is := is.New(t)
+	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
+
+	underTest, err := newJSRequestBuilder(
+		ctx,
+		map[string]string{
+			"url": "http://example.com/_search",
+		},
+		"./test/get_request_data_body.js",
+		0,
+	)
+	is.NoErr(err)
+
+	data, err := underTest.build(ctx, map[string]any{}, opencdc.Position(""))
+	is.NoErr(err)
+	is.Equal("http://example.com/_search", data.URL)
+	is.Equal(`{"query":{"match_all":{}},"size":2}`, data.Body)
+}
+
+func TestSourceExtension_Sleep(t *testing.T) {
+	is := is.New(t)
+	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
+
+	cfg := map[string]string{
+		"url":   "http://example.com",
+		"sleep": "50",
+	}
+	underTest, err := newJSRequestBuilder(ctx, cfg, "./test/get_request_data_sleep.js", 0)
+	is.NoErr(err)
+
+	start := time.Now()
+	data, err := underTest.build(ctx, nil, opencdc.Position(""))
+	is.NoErr(err)
+	is.Equal("http://example.com", data.URL)
+	is.True(time.Since(start) >= 50*time.Millisecond)
+
+	// the script is interrupted when the context is done
+	cfg["sleep"] = "60000"
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = underTest.build(timeoutCtx, nil, opencdc.Position(""))
+	is.True(err != nil)
+	is.True(time.Since(start) < 5*time.Second)
+
+	// the runtime can be used again afterwards
+	cfg["sleep"] = "0"
+	_, err = underTest.build(ctx, nil, opencdc.Position(""))
+	is.NoErr(err)
+}
+
+func TestSourceExtension_ScriptTimeout(t *testing.T) {
+	is := is.New(t)
+	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
+
+	parser, err := newJSResponseParser(ctx, "./test/parse_response_loop.js", 50*time.Millisecond)
+	is.NoErr(err)
+	_, err = parser.parse(ctx, []byte(`{}`), responseMetadata{StatusCode: http.StatusOK})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "parseResponse didn't finish within script.timeout (50ms)"))
+
+	cfg := map[string]string{"url": "http://example.com", "sleep": "60000"}
+	builder, err := newJSRequestBuilder(ctx, cfg, "./test/get_request_data_sleep.js", 50*time.Millisecond)
+	is.NoErr(err)
+	start := time.Now()
+	_, err = builder.build(ctx, nil, opencdc.Position(""))
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "getRequestData didn't finish within script.timeout (50ms)"))
+	is.True(time.Since(start) < 5*time.Second)
+}
+
+func TestSourceExtension_InlineScript(t *testing.T) {
+	is := is.New(t)
+	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
+
+	underTest, err := newJSRequestBuilder(
+		ctx,
+		map[string]string{"url": "http://example.com"},
+		`function getRequestData(cfg, previousResponse, position) {
+			let request = new Request()
+			request.URL = cfg["url"] + "/inline"
+			return request
+		}`,
+		0,
+	)
+	is.NoErr(err)
+
+	data, err := underTest.build(ctx, nil, opencdc.Position(""))
+	is.NoErr(err)
+	is.Equal("http://example.com/inline", data.URL)
+}
+
+func TestReadScript(t *testing.T) {
+	is := is.New(t)
+
+	src, err := readScript("function parseResponse(bytes, response) {}")
+	is.NoErr(err)
+	is.Equal(src, "function parseResponse(bytes, response) {}")
+
+	src, err = readScript("./test/parse_response.js")
+	is.NoErr(err)
+	is.True(strings.Contains(src, "function parseResponse"))
+
+	// paths are read, even if they contain the keyword
+	_, err = readScript("./test/functions/does-not-exist.js")
+	is.True(err != nil)
+}
+
+func TestValidateScript(t *testing.T) {
+	testCases := []struct {
+		name    string
+		script  string
+		fnName  string
+		wantErr string
+	}{{
+		name:   "file",
+		script: "./test/get_request_data.js",
+		fnName: getRequestDataFn,
+	}, {
+		name:   "variable",
+		script: "var parseResponse = function(bytes, response) {}",
+		fnName: parseResponseFn,
+	}, {
+		name:   "assignment",
+		script: "parseResponse = function(bytes, response) {}",
+		fnName: parseResponseFn,
+	}, {
+		name:    "missing file",
+		script:  "./test/does-not-exist.js",
+		fnName:  getRequestDataFn,
+		wantErr: "failed reading file ./test/does-not-exist.js",
+	}, {
+		name:    "syntax error",
+		script:  "function getRequestData(cfg {}",
+		fnName:  getRequestDataFn,
+		wantErr: "failed to compile script: inline script: Line 1:29 Unexpected token",
+	}, {
+		name:    "wrong function",
+		script:  "./test/get_request_data.js",
+		fnName:  parseResponseFn,
+		wantErr: `./test/get_request_data.js doesn't declare the function "parseResponse"`,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			err := validateScript(tc.script, tc.fnName)
+			if tc.wantErr == "" {
+				is.NoErr(err)
+				return
+			}
+			is.True(err != nil)
+			is.True(strings.Contains(err.Error(), tc.wantErr)) // unexpected error
+		})
+	}
+}
+
+func TestValidateScript_NotRun(t *testing.T) {
+	is := is.New(t)
+
+	// the script would fail when it's run, but it's only compiled
+	err := validateScript(`throw new Error("side effect")
+		function parseResponse(bytes, response) {}`, parseResponseFn)
+	is.NoErr(err)
+}
+
+func TestSourceExtension_Crypto(t *testing.T) {
+	is := is.New(t)
+	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
+
+	underTest, err := newJSRequestBuilder(
+		ctx,
+		map[string]string{
+			"url":    "http://example.com",
+			"secret": "c2VjcmV0", // "secret"
+		},
+		"./test/get_request_data_signed.js",
+		0,
+	)
+	is.NoErr(err)
+
+	data, err := underTest.build(ctx, nil, opencdc.Position(""))
+	is.NoErr(err)
+	is.Equal(data.Body, `{"id":1}`)
+	is.Equal(data.Headers, map[string]string{
+		"X-Content-SHA256": "037c9214eef74cc3887f3a4f085b4e17d76280dafd273b0ee160c09c4ba1cfd4",
+		"X-Signature":      "03def589620c813f198fd03d7967e292b163ef0435ebf43071ce0e9519763cb7",
+		"X-Client":         "Y29uZHVpdA==",
+	})
+}
+
 func TestSourceExtension_ParseResponse(t *testing.T) {
 	is := is.New(t)
 	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
 
-	underTest, err := newJSResponseParser(ctx, "./test/parse_response.js")
+	underTest, err := newJSResponseParser(ctx, "./test/parse_response.js", 0)
 	is.NoErr(err)
 
 	resp, err := underTest.parse(
@@ -72,6 +267,7 @@ func TestSourceExtension_ParseResponse(t *testing.T) {
 				}
 			]
 		}`),
+		responseMetadata{StatusCode: http.StatusOK},
 	)
 	is.NoErr(err)
 
@@ -102,3 +298,27 @@ func TestSourceExtension_ParseResponse(t *testing.T) {
 	)
 	is.Equal("", diff)
 }
+
+func TestSourceExtension_ParseResponseMetadata(t *testing.T) {
+	is := is.New(t)
+	ctx := zerolog.New(zerolog.NewTestWriter(t)).WithContext(context.Background())
+
+	underTest, err := newJSResponseParser(ctx, "./test/parse_response_metadata.js", 0)
+	is.NoErr(err)
+
+	resp, err := underTest.parse(
+		ctx,
+		[]byte(`[]`),
+		responseMetadata{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Link": []string{`<http://example.com/?page=2>; rel="next"`},
+			},
+			Request: &Request{URL: "http://example.com/?page=1"},
+		},
+	)
+	is.NoErr(err)
+	is.Equal(resp.CustomData["statusCode"], int64(http.StatusPartialContent))
+	is.Equal(resp.CustomData["link"], `<http://example.com/?page=2>; rel="next"`)
+	is.Equal(resp.CustomData["requestURL"], "http://example.com/?page=1")
+}
@@ -0,0 +1,265 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	// cursorKey is the key under which the cursor extracted by the JSON response
+	// parser is stored in the response data.
+	cursorKey = "cursor"
+	// metadataRecordType contains the name of the records array a record was
+	// extracted from, when using response.recordsPaths.
+	metadataRecordType = "http.response.recordType"
+)
+
+// jsonResponseParser is a response parser that splits a JSON response into
+// records based on the configured paths, without requiring a script.
+type jsonResponseParser struct {
+	arrays     []recordsArray
+	cursorPath string
+	useNumber  bool
+	operations *operationMapper
+}
+
+// recordsArray is an array of records in a JSON response.
+type recordsArray struct {
+	// name is added to the metadata of the records, unless it's empty
+	name    string
+	path    string
+	keyPath string
+}
+
+func newJSONResponseParser(cfg SourceConfig, operations *operationMapper) (*jsonResponseParser, error) {
+	arrays, err := cfg.recordsArrays()
+	if err != nil {
+		return nil, err
+	}
+	return &jsonResponseParser{
+		arrays:     arrays,
+		cursorPath: cfg.ResponseCursorPath,
+		useNumber:  cfg.ResponseUseNumber,
+		operations: operations,
+	}, nil
+}
+
+// recordsArrays returns the arrays records are extracted from, which is either
+// response.recordsPath or the named arrays in response.recordsPaths, each with
+// the key path from response.keyPaths or response.keyPath.
+func (s *SourceConfig) recordsArrays() ([]recordsArray, error) {
+	if len(s.ResponseRecordsPaths) == 0 {
+		return []recordsArray{{path: s.ResponseRecordsPath, keyPath: s.ResponseKeyPath}}, nil
+	}
+
+	keyPaths := make(map[string]string, len(s.ResponseKeyPaths))
+	for _, pair := range s.ResponseKeyPaths {
+		name, path, found := strings.Cut(pair, ":")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid response.keyPaths %q, expected a name and a path separated by a colon, e.g. users:id", pair)
+		}
+		keyPaths[name] = path
+	}
+
+	arrays := make([]recordsArray, 0, len(s.ResponseRecordsPaths))
+	for _, pair := range s.ResponseRecordsPaths {
+		name, path, found := strings.Cut(pair, ":")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid response.recordsPaths %q, expected a name and a path separated by a colon, e.g. users:data.users", pair)
+		}
+		arr := recordsArray{name: name, path: path, keyPath: s.ResponseKeyPath}
+		if keyPath, ok := keyPaths[name]; ok {
+			arr.keyPath = keyPath
+			delete(keyPaths, name)
+		}
+		arrays = append(arrays, arr)
+	}
+	if len(keyPaths) > 0 {
+		return nil, fmt.Errorf("response.keyPaths contains names that aren't in response.recordsPaths: %v", slices.Sorted(maps.Keys(keyPaths)))
+	}
+	return arrays, nil
+}
+
+func (p *jsonResponseParser) parse(_ context.Context, responseBytes []byte, _ responseMetadata) (*Response, error) {
+	var data any
+	err := decodeJSON(responseBytes, &data, p.useNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding JSON response: %w", err)
+	}
+
+	resp := &Response{CustomData: map[string]any{}}
+	if p.cursorPath != "" {
+		if cursor, ok := lookupPath(data, p.cursorPath); ok && cursor != nil {
+			resp.CustomData[cursorKey] = cursorValue(cursor)
+		}
+	}
+
+	for _, arr := range p.arrays {
+		val, ok := lookupPath(data, arr.path)
+		if !ok || val == nil {
+			// no records in the response
+			continue
+		}
+		items, ok := val.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an array at path %q, got %T", arr.path, val)
+		}
+
+		for _, item := range items {
+			rec, err := p.toRecord(item, arr)
+			if err != nil {
+				return nil, err
+			}
+			resp.Records = append(resp.Records, rec)
+		}
+	}
+	return resp, nil
+}
+
+func (p *jsonResponseParser) toRecord(item any, arr recordsArray) (*jsRecord, error) {
+	rec := &jsRecord{
+		Operation: p.operations.forItem(item).String(),
+		Metadata:  map[string]string{},
+	}
+	if arr.name != "" {
+		rec.Metadata[metadataRecordType] = arr.name
+	}
+
+	switch v := item.(type) {
+	case map[string]any:
+		rec.Payload.After = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed encoding record: %w", err)
+		}
+		rec.Payload.After = opencdc.RawData(b)
+	}
+
+	if arr.keyPath != "" {
+		if key, ok := lookupPath(item, arr.keyPath); ok && key != nil {
+			rec.Key = opencdc.RawData(formatValue(key))
+			rec.Position = []byte(formatValue(key))
+		}
+	}
+	return rec, nil
+}
+
+// decodeJSON decodes JSON data into v, like json.Unmarshal. With useNumber,
+// numbers are decoded as json.Number instead of float64, which keeps their
+// exact value.
+func decodeJSON(data []byte, v any, useNumber bool) error {
+	if !useNumber {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid data after top-level value")
+	}
+	return nil
+}
+
+// lookupPayloadPath returns the value at the path in a record payload, which
+// is either structured data or raw data containing JSON.
+func lookupPayloadPath(payload opencdc.Data, path string) (any, bool) {
+	var data any
+	switch v := payload.(type) {
+	case opencdc.StructuredData:
+		data = map[string]any(v)
+	default:
+		if err := json.Unmarshal(payload.Bytes(), &data); err != nil {
+			return nil, false
+		}
+	}
+	val, ok := lookupPath(data, path)
+	return val, ok && val != nil
+}
+
+// lookupPath returns the value at the dot separated path in data decoded from
+// JSON, e.g. "data.items" or "items.0.id". A leading "$." (as used in JSONPath)
+// is ignored.
+func lookupPath(data any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return data, true
+	}
+
+	current := data
+	for _, token := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			val, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			current = val
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			current = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// cursorValue returns the cursor that's stored in the response data. A scalar
+// is stored as a string, so that a numeric cursor keeps its exact value in
+// templates and positions, e.g. a Unix timestamp isn't rendered as 1.7e+09.
+func cursorValue(cursor any) any {
+	switch cursor.(type) {
+	case map[string]any, []any:
+		return cursor
+	default:
+		return formatValue(cursor)
+	}
+}
+
+// formatValue formats a value decoded from JSON as a string. Numbers are
+// formatted with their exact value, without an exponent, e.g. 1000000 instead
+// of 1e+06 as formatted by fmt, and the text of a json.Number is used as is.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestJSONResponseParser_Parse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	underTest := &jsonResponseParser{
+		arrays:     []recordsArray{{path: "data.items", keyPath: "id"}},
+		cursorPath: "$.meta.next",
+		operations: &operationMapper{fallback: opencdc.OperationCreate},
+	}
+
+	resp, err := underTest.parse(ctx, []byte(`{
+		"meta": {"next": "abc"},
+		"data": {"items": [
+			{"id": 1, "name": "first"},
+			{"id": "two", "name": "second"},
+			"third"
+		]}
+	}`), responseMetadata{})
+	is.NoErr(err)
+
+	is.Equal(resp.CustomData, map[string]any{"cursor": "abc"})
+	is.Equal(cmp.Diff([]*jsRecord{
+		{
+			Position:  []byte("1"),
+			Operation: "create",
+			Metadata:  map[string]string{},
+			Key:       opencdc.RawData("1"),
+			Payload:   jsPayload{After: map[string]any{"id": float64(1), "name": "first"}},
+		},
+		{
+			Position:  []byte("two"),
+			Operation: "create",
+			Metadata:  map[string]string{},
+			Key:       opencdc.RawData("two"),
+			Payload:   jsPayload{After: map[string]any{"id": "two", "name": "second"}},
+		},
+		{
+			Operation: "create",
+			Metadata:  map[string]string{},
+			Payload:   jsPayload{After: opencdc.RawData(`"third"`)},
+		},
+	}, resp.Records), "")
+}
+
+func TestJSONResponseParser_NotAnArray(t *testing.T) {
+	is := is.New(t)
+
+	underTest := &jsonResponseParser{arrays: []recordsArray{{path: "data"}}}
+	_, err := underTest.parse(context.Background(), []byte(`{"data": {"id": 1}}`), responseMetadata{})
+	is.True(err != nil)
+}
+
+func TestJSONResponseParser_NumericKey(t *testing.T) {
+	is := is.New(t)
+
+	underTest := &jsonResponseParser{
+		arrays:     []recordsArray{{path: "items", keyPath: "id"}},
+		operations: &operationMapper{fallback: opencdc.OperationCreate},
+	}
+	resp, err := underTest.parse(context.Background(), []byte(`{"items": [{"id": 1234567}, {"id": 1000000}]}`), responseMetadata{})
+	is.NoErr(err)
+	is.Equal(resp.Records[0].Key, opencdc.RawData("1234567"))
+	is.Equal(resp.Records[0].Position, []byte("1234567"))
+	is.Equal(resp.Records[1].Key, opencdc.RawData("1000000"))
+}
+
+func TestFormatValue(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(formatValue(float64(1234567)), "1234567")
+	is.Equal(formatValue(1.5), "1.5")
+	is.Equal(formatValue("abc"), "abc")
+	is.Equal(formatValue(json.Number("12345678901234567890")), "12345678901234567890")
+	is.Equal(formatValue(true), "true")
+}
+
+func TestJSONResponseParser_UseNumber(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	underTest := &jsonResponseParser{
+		arrays:     []recordsArray{{path: "items", keyPath: "id"}},
+		useNumber:  true,
+		operations: &operationMapper{fallback: opencdc.OperationCreate},
+	}
+
+	resp, err := underTest.parse(ctx, []byte(`{"items": [{"id": 1234567890123456789, "score": 1.5}]}`), responseMetadata{})
+	is.NoErr(err)
+	is.Equal(len(resp.Records), 1)
+	is.Equal(resp.Records[0].Key, opencdc.RawData("1234567890123456789"))
+
+	payload := opencdc.StructuredData(resp.Records[0].Payload.After.(map[string]any))
+	is.Equal(string(payload.Bytes()), `{"id":1234567890123456789,"score":1.5}`)
+
+	// without useNumber, the ID is rounded
+	underTest.useNumber = false
+	resp, err = underTest.parse(ctx, []byte(`{"items": [{"id": 1234567890123456789}]}`), responseMetadata{})
+	is.NoErr(err)
+	is.Equal(resp.Records[0].Key, opencdc.RawData("1234567890123456800"))
+}
+
+func TestDecodeJSON(t *testing.T) {
+	is := is.New(t)
+
+	var data any
+	err := decodeJSON([]byte(`{"id": 1} `), &data, true)
+	is.NoErr(err)
+	is.Equal(data, map[string]any{"id": json.Number("1")})
+
+	err = decodeJSON([]byte(`{"id": 1} {}`), &data, true)
+	is.True(err != nil)
+}
+
+func TestLookupPath(t *testing.T) {
+	is := is.New(t)
+	data := map[string]any{
+		"a": map[string]any{
+			"b": []any{map[string]any{"c": "found"}},
+		},
+	}
+
+	got, ok := lookupPath(data, "a.b.0.c")
+	is.True(ok)
+	is.Equal(got, "found")
+
+	got, ok = lookupPath(data, "$")
+	is.True(ok)
+	is.Equal(got, data)
+
+	_, ok = lookupPath(data, "a.b.1.c")
+	is.True(!ok)
+	_, ok = lookupPath(data, "a.x")
+	is.True(!ok)
+}
+
+func TestSource_RecordsPath(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"items": [{"id": "a"}, {"id": "b"}]}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                  server.URL,
+		"response.recordsPath": "data.items",
+		"response.keyPath":     "id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"a", "b"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Key, opencdc.RawData(want))
+		is.Equal(rec.Operation, opencdc.OperationCreate)
+		is.Equal(rec.Payload.After, opencdc.StructuredData{"id": want})
+	}
+}
+
+func TestSource_RecordsPaths(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"users": [{"id": "u1"}], "data": {"orders": [{"order": {"id": "o1"}}, {"order": {"id": "o2"}}]}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                   server.URL,
+		"response.recordsPaths": "users:users,orders:data.orders",
+		"response.keyPath":      "id",
+		"response.keyPaths":     "orders:order.id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []struct{ key, recordType string }{
+		{key: "u1", recordType: "users"},
+		{key: "o1", recordType: "orders"},
+		{key: "o2", recordType: "orders"},
+	} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Key, opencdc.RawData(want.key))
+		is.Equal(rec.Metadata["http.response.recordType"], want.recordType)
+	}
+}
+
+func TestSourceConfig_RecordsArrays(t *testing.T) {
+	is := is.New(t)
+
+	cfg := SourceConfig{ResponseRecordsPath: "items", ResponseKeyPath: "id"}
+	got, err := cfg.recordsArrays()
+	is.NoErr(err)
+	is.Equal(got, []recordsArray{{path: "items", keyPath: "id"}})
+
+	cfg = SourceConfig{
+		ResponseRecordsPaths: []string{"users:users", " orders : data.orders "},
+		ResponseKeyPaths:     []string{"orders:order.id"},
+	}
+	got, err = cfg.recordsArrays()
+	is.NoErr(err)
+	is.Equal(got, []recordsArray{
+		{name: "users", path: "users"},
+		{name: "orders", path: "data.orders", keyPath: "order.id"},
+	})
+
+	for _, invalid := range []SourceConfig{
+		{ResponseRecordsPaths: []string{"users"}},
+		{ResponseRecordsPaths: []string{":users"}},
+		{ResponseRecordsPaths: []string{"users:users"}, ResponseKeyPaths: []string{"id"}},
+		{ResponseRecordsPaths: []string{"users:users"}, ResponseKeyPaths: []string{"orders:id"}},
+	} {
+		_, err = invalid.recordsArrays()
+		is.True(err != nil)
+	}
+}
+
+func TestSource_KeyPathSingleRecord(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"user": {"id": 42, "name": "jane"}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"response.keyPath": "user.id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Key, opencdc.RawData("42"))
+	is.Equal(string(rec.Payload.After.Bytes()), `{"user": {"id": 42, "name": "jane"}}`)
+}
+
+func TestSource_NumericKeyPath(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1234567, "name": "a"}`+"\n")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"responseFormat":   "ndjson",
+		"response.keyPath": "id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Key, opencdc.RawData("1234567"))
+}
@@ -0,0 +1,113 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// loggingRoundTripper logs the requests sent and the responses received using
+// the base round tripper, based on the logging options in the config.
+type loggingRoundTripper struct {
+	base http.RoundTripper
+	cfg  *Config
+}
+
+func (t *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := sdk.Logger(req.Context())
+	if t.cfg.LogRequests {
+		logger.Trace().
+			Str("method", req.Method).
+			Str("url", t.logURL(req.URL)).
+			Any("headers", t.cfg.redactHeaders(req.Header)).
+			Str("body", t.cfg.redactSecrets(t.requestBody(req))).
+			Msg("sending HTTP request")
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if !t.cfg.LogResponses {
+		return resp, err
+	}
+	if err != nil {
+		logger.Trace().
+			Str("method", req.Method).
+			Str("url", t.logURL(req.URL)).
+			Dur("duration", time.Since(start)).
+			Err(t.cfg.redactError(err)).
+			Msg("HTTP request failed")
+		return resp, err
+	}
+	logger.Trace().
+		Str("method", req.Method).
+		Str("url", t.logURL(req.URL)).
+		Int("status", resp.StatusCode).
+		Dur("duration", time.Since(start)).
+		Any("headers", t.cfg.redactHeaders(resp.Header)).
+		Str("body", t.cfg.redactSecrets(string(peekBody(resp, int64(t.cfg.LogMaxBodyBytes))))).
+		Msg("received HTTP response")
+	return resp, nil
+}
+
+// CloseIdleConnections closes the idle connections of the base round tripper,
+// it's called by http.Client.CloseIdleConnections.
+func (t *loggingRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(t.base)
+}
+
+func (t *loggingRoundTripper) logURL(u *url.URL) string {
+	if !t.cfg.LogRedactQuery || u.RawQuery == "" {
+		return t.cfg.redactURL(u.String())
+	}
+	redactedURL := *u
+	redactedURL.RawQuery = redacted
+	return redactedURL.String()
+}
+
+// requestBody returns the beginning of the request body, without consuming
+// the body that is sent.
+func (t *loggingRoundTripper) requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(io.LimitReader(body, int64(t.cfg.LogMaxBodyBytes)))
+	return string(data)
+}
+
+// peekBody returns up to n bytes from the beginning of the response body. The
+// read bytes are put back, so the whole body can still be read from the
+// response.
+func peekBody(resp *http.Response, n int64) []byte {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, n))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(data), resp.Body),
+		Closer: resp.Body,
+	}
+	return data
+}
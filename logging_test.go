@@ -0,0 +1,160 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+	"github.com/rs/zerolog"
+)
+
+func TestSource_LogRequestsAndResponses(t *testing.T) {
+	is := is.New(t)
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).Level(zerolog.TraceLevel).WithContext(context.Background())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret-session"})
+		fmt.Fprint(w, "a response body that is too long to be logged")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":             server.URL + "?api_key=secret-key",
+		"method":          "POST",
+		"requestBody":     "request body",
+		"headers":         "Authorization:Bearer secret-token",
+		"logRequests":     "true",
+		"logResponses":    "true",
+		"logRedactQuery":  "true",
+		"logMaxBodyBytes": "15",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	// the whole body is still read
+	is.Equal(string(rec.Payload.After.Bytes()), "a response body that is too long to be logged")
+
+	out := logs.String()
+	is.True(strings.Contains(out, `"level":"trace"`))
+	is.True(strings.Contains(out, `"message":"sending HTTP request"`))
+	is.True(strings.Contains(out, `"message":"received HTTP response"`))
+	is.True(strings.Contains(out, `"message":"request completed"`))
+	is.True(strings.Contains(out, `"body":"request body"`))
+	is.True(strings.Contains(out, `"body":"a response body"`))
+	is.True(strings.Contains(out, `"status":200`))
+	is.True(strings.Contains(out, `?***`))
+	for _, secret := range []string{"secret-token", "secret-session", "secret-key"} {
+		is.True(!strings.Contains(out, secret))
+	}
+}
+
+func TestSource_LogRequestsOAuth2(t *testing.T) {
+	is := is.New(t)
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).WithContext(context.Background())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"secret-access-token","token_type":"bearer","expires_in":3600,"refresh_token":"secret-rotated"}`)
+		case "/resource":
+			// echoes a credential
+			fmt.Fprint(w, "resource secret-client secret-refresh-token")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                      server.URL + "/resource",
+		"auth.oauth2.tokenURL":     server.URL + "/token",
+		"auth.oauth2.clientID":     "client-id",
+		"auth.oauth2.clientSecret": "secret-client",
+		"auth.oauth2.refreshToken": "secret-refresh-token",
+		"logRequests":              "true",
+		"logResponses":             "true",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.NoErr(err)
+
+	out := logs.String()
+	is.True(strings.Contains(out, `"body":"resource *** ***"`))
+	// token requests aren't logged
+	is.True(!strings.Contains(out, "/token"))
+	for _, secret := range []string{"secret-access-token", "secret-client", "secret-refresh-token", "secret-rotated"} {
+		is.True(!strings.Contains(out, secret))
+	}
+}
+
+func TestDestination_CaptureResponse(t *testing.T) {
+	is := is.New(t)
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).WithContext(context.Background())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"srv-123"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":             server.URL,
+		"captureResponse": "true",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Position: opencdc.Position("pos-1"),
+		Key:      opencdc.RawData("key-1"),
+	}})
+	is.NoErr(err)
+
+	out := logs.String()
+	is.True(strings.Contains(out, `"message":"captured HTTP response"`))
+	is.True(strings.Contains(out, `"key":"key-1"`))
+	is.True(strings.Contains(out, `"position":"pos-1"`))
+	is.True(strings.Contains(out, `"status":201`))
+	is.True(strings.Contains(out, `"duration":`))
+	is.True(strings.Contains(out, `"body":"{\"id\":\"srv-123\"}"`))
+	// the duration of each request is logged at debug level
+	is.True(strings.Contains(out, `"message":"request completed"`))
+}
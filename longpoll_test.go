@@ -0,0 +1,122 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/matryer/is"
+)
+
+// createLongPollServer creates a server that holds GET requests open until an
+// event is sent to the returned channel.
+func createLongPollServer(t *testing.T) (string, chan<- string) {
+	events := make(chan string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		select {
+		case event := <-events:
+			fmt.Fprint(w, event)
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL, events
+}
+
+func TestSource_LongPoll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	url, events := createLongPollServer(t)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":           url,
+		"mode":          "longpoll",
+		"pollingPeriod": "1h",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"event-1", "event-2"} {
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			events <- want
+		}()
+		// the polling period isn't respected, the request is sent right away
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(rec.Payload.After.Bytes()), want)
+	}
+}
+
+func TestSource_LongPollMaxIdle(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	url, _ := createLongPollServer(t)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              url,
+		"mode":             "longpoll",
+		"longPoll.maxIdle": "50ms",
+		// doesn't apply to long-poll requests
+		"requestTimeout": "10ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	start := time.Now()
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, sdk.ErrBackoffRetry))
+	is.True(time.Since(start) >= 50*time.Millisecond)
+}
+
+func TestSource_LongPollCanceled(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	url, _ := createLongPollServer(t)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":  url,
+		"mode": "longpoll",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	readCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = src.Read(readCtx)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.True(time.Since(start) < 5*time.Second)
+}
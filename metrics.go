@@ -0,0 +1,117 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"expvar"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// metricsName is the name under which the request metrics are published
+// using expvar.
+const metricsName = "conduit_connector_http"
+
+// durationBuckets are the upper bounds of the request duration histogram in
+// seconds, the last bucket counts all requests.
+var durationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// requestMetrics records metrics about the requests sent by the connector.
+type requestMetrics interface {
+	observe(req *http.Request, resp *http.Response, duration time.Duration, err error)
+}
+
+var (
+	expvarMetricsOnce sync.Once
+	expvarMetricsInst *expvarMetrics
+)
+
+// expvarMetrics publishes request metrics using expvar. The metrics are
+// shared by all connectors running in the same process.
+type expvarMetrics struct {
+	// number of requests by response status code, or "error" for requests
+	// that didn't get a response
+	requests *expvar.Map
+	// number of requests by method
+	methods *expvar.Map
+	// cumulative histogram of request durations, the keys are the upper
+	// bounds of the buckets in seconds
+	durationBuckets *expvar.Map
+	durationSum     *expvar.Float
+	durationCount   *expvar.Int
+}
+
+// getExpvarMetrics returns the expvar metrics, publishing them on first use.
+func getExpvarMetrics() *expvarMetrics {
+	expvarMetricsOnce.Do(func() {
+		m := &expvarMetrics{
+			requests:        new(expvar.Map).Init(),
+			methods:         new(expvar.Map).Init(),
+			durationBuckets: new(expvar.Map).Init(),
+			durationSum:     new(expvar.Float),
+			durationCount:   new(expvar.Int),
+		}
+		root := expvar.NewMap(metricsName)
+		root.Set("requests_total", m.requests)
+		root.Set("requests_by_method_total", m.methods)
+		root.Set("request_duration_seconds_bucket", m.durationBuckets)
+		root.Set("request_duration_seconds_sum", m.durationSum)
+		root.Set("request_duration_seconds_count", m.durationCount)
+		expvarMetricsInst = m
+	})
+	return expvarMetricsInst
+}
+
+func (m *expvarMetrics) observe(req *http.Request, resp *http.Response, duration time.Duration, err error) {
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	m.requests.Add(status, 1)
+	m.methods.Add(req.Method, 1)
+
+	seconds := duration.Seconds()
+	for _, bound := range durationBuckets {
+		if seconds <= bound {
+			m.durationBuckets.Add(strconv.FormatFloat(bound, 'f', -1, 64), 1)
+		}
+	}
+	m.durationBuckets.Add("+Inf", 1)
+	m.durationSum.Add(seconds)
+	m.durationCount.Add(1)
+}
+
+// metricsRoundTripper records metrics for each request sent using the base
+// round tripper. The duration is measured until the response headers are
+// received.
+type metricsRoundTripper struct {
+	base    http.RoundTripper
+	metrics requestMetrics
+}
+
+func (t *metricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.metrics.observe(req, resp, time.Since(start), err)
+	return resp, err
+}
+
+// CloseIdleConnections closes the idle connections of the base round tripper,
+// it's called by http.Client.CloseIdleConnections.
+func (t *metricsRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(t.base)
+}
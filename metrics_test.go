@@ -0,0 +1,66 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestDestination_Metrics(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	m := getExpvarMetrics()
+	count := func(vars *expvar.Map, key string) int64 {
+		if v, ok := vars.Get(key).(*expvar.Int); ok {
+			return v.Value()
+		}
+		return 0
+	}
+	accepted, puts, total := count(m.requests, "202"), count(m.methods, http.MethodPut), m.durationCount.Value()
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":             server.URL,
+		"method":          http.MethodPut,
+		"metrics.enabled": "true",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{}, {}})
+	is.NoErr(err)
+
+	is.Equal(count(m.requests, "202"), accepted+2)
+	is.Equal(count(m.methods, http.MethodPut), puts+2)
+	// the connection test is counted too
+	is.Equal(m.durationCount.Value(), total+3)
+	is.True(expvar.Get(metricsName) != nil)
+}
@@ -81,16 +81,16 @@ func (m *MockresponseParser) EXPECT() *MockresponseParserMockRecorder {
 }
 
 // parse mocks base method.
-func (m *MockresponseParser) parse(ctx context.Context, responseBytes []byte) (*Response, error) {
+func (m *MockresponseParser) parse(ctx context.Context, responseBytes []byte, meta responseMetadata) (*Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "parse", ctx, responseBytes)
+	ret := m.ctrl.Call(m, "parse", ctx, responseBytes, meta)
 	ret0, _ := ret[0].(*Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // parse indicates an expected call of parse.
-func (mr *MockresponseParserMockRecorder) parse(ctx, responseBytes any) *gomock.Call {
+func (mr *MockresponseParserMockRecorder) parse(ctx, responseBytes, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "parse", reflect.TypeOf((*MockresponseParser)(nil).parse), ctx, responseBytes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "parse", reflect.TypeOf((*MockresponseParser)(nil).parse), ctx, responseBytes, meta)
 }
@@ -81,16 +81,16 @@ func (m *MockResponseParser) EXPECT() *MockResponseParserMockRecorder {
 }
 
 // parse mocks base method.
-func (m *MockResponseParser) parse(ctx context.Context, responseBytes []byte) (*Response, error) {
+func (m *MockResponseParser) parse(ctx context.Context, responseBytes []byte, meta responseMetadata) (*Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "parse", ctx, responseBytes)
+	ret := m.ctrl.Call(m, "parse", ctx, responseBytes, meta)
 	ret0, _ := ret[0].(*Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // parse indicates an expected call of parse.
-func (mr *MockResponseParserMockRecorder) parse(ctx, responseBytes any) *gomock.Call {
+func (mr *MockResponseParserMockRecorder) parse(ctx, responseBytes, meta any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "parse", reflect.TypeOf((*MockResponseParser)(nil).parse), ctx, responseBytes)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "parse", reflect.TypeOf((*MockResponseParser)(nil).parse), ctx, responseBytes, meta)
 }
@@ -0,0 +1,87 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const contentTypeMultipart = "multipart"
+
+// multipartField is a metadata field that's sent as a part of a multipart
+// request body.
+type multipartField struct {
+	metadataKey string
+	name        string
+}
+
+// parseMultipartFields parses the configured metadata fields, which are either
+// a metadata key or a metadata key followed by a colon and the field name.
+func parseMultipartFields(fields []string) ([]multipartField, error) {
+	parsed := make([]multipartField, 0, len(fields))
+	for _, f := range fields {
+		key, name, ok := strings.Cut(strings.TrimSpace(f), ":")
+		if !ok {
+			name = key
+		}
+		key, name = strings.TrimSpace(key), strings.TrimSpace(name)
+		if key == "" || name == "" {
+			return nil, fmt.Errorf("invalid multipart metadata field %q", f)
+		}
+		parsed = append(parsed, multipartField{metadataKey: key, name: name})
+	}
+	return parsed, nil
+}
+
+// multipartBody encodes the record as a multipart/form-data body, with the
+// configured metadata fields followed by a file part containing the payload.
+// It returns the body and its content type, which includes the boundary.
+func (d *Destination) multipartBody(rec opencdc.Record) ([]byte, string, error) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	for _, f := range d.multipartFields {
+		val, ok := rec.Metadata[f.metadataKey]
+		if !ok {
+			continue
+		}
+		err := w.WriteField(f.name, val)
+		if err != nil {
+			return nil, "", fmt.Errorf("error writing multipart field %q: %w", f.name, err)
+		}
+	}
+
+	part, err := w.CreateFormFile(d.config.MultipartFileField, d.config.MultipartFileName)
+	if err != nil {
+		return nil, "", fmt.Errorf("error writing multipart file: %w", err)
+	}
+	if rec.Payload.After != nil {
+		_, err = part.Write(rec.Payload.After.Bytes())
+		if err != nil {
+			return nil, "", fmt.Errorf("error writing multipart file: %w", err)
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, "", fmt.Errorf("error writing multipart body: %w", err)
+	}
+	return b.Bytes(), w.FormDataContentType(), nil
+}
@@ -0,0 +1,94 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestDestination_Multipart(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var (
+		fields   map[string][]string
+		fileName string
+		file     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		err := r.ParseMultipartForm(1 << 20)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fields = r.MultipartForm.Value
+		f, header, err := r.FormFile("upload")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		fileName = header.Filename
+		b, _ := io.ReadAll(f)
+		file = string(b)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":                      server.URL,
+		"headers":                  "Content-Type:application/json",
+		"contentType":              "multipart",
+		"multipart.fileField":      "upload",
+		"multipart.fileName":       "attachment.bin",
+		"multipart.metadataFields": "file.name:name,source,missing",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Metadata: opencdc.Metadata{"file.name": "report.pdf", "source": "crm"},
+		Payload:  opencdc.Change{After: opencdc.RawData("\x00\x01binary")},
+	}})
+	is.NoErr(err)
+	is.Equal(fields, map[string][]string{"name": {"report.pdf"}, "source": {"crm"}})
+	is.Equal(fileName, "attachment.bin")
+	is.Equal(file, "\x00\x01binary")
+}
+
+func TestParseMultipartFields(t *testing.T) {
+	is := is.New(t)
+
+	got, err := parseMultipartFields([]string{"file.name:name", " source "})
+	is.NoErr(err)
+	is.Equal(got, []multipartField{
+		{metadataKey: "file.name", name: "name"},
+		{metadataKey: "source", name: "source"},
+	})
+
+	_, err = parseMultipartFields([]string{"file.name:"})
+	is.True(err != nil)
+}
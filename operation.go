@@ -0,0 +1,103 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// operationMapper determines the operation of records that are parsed without
+// a script, based on response.operation, response.operationPath and
+// response.operationMapping.
+type operationMapper struct {
+	path     string
+	mapping  map[string]opencdc.Operation
+	fallback opencdc.Operation
+}
+
+func newOperationMapper(cfg SourceConfig) (*operationMapper, error) {
+	fallback, ok := parseOperation(cfg.ResponseOperation)
+	if !ok {
+		return nil, fmt.Errorf("invalid response.operation %q", cfg.ResponseOperation)
+	}
+	m := &operationMapper{
+		path:     cfg.ResponseOperationPath,
+		mapping:  make(map[string]opencdc.Operation, len(cfg.ResponseOperationMapping)),
+		fallback: fallback,
+	}
+
+	for _, pair := range cfg.ResponseOperationMapping {
+		val, opStr, found := strings.Cut(pair, ":")
+		val = strings.TrimSpace(val)
+		op, ok := parseOperation(opStr)
+		if !found || val == "" || !ok {
+			return nil, fmt.Errorf("invalid response.operationMapping %q, expected a value and an operation separated by a colon, e.g. deleted:delete", pair)
+		}
+		m.mapping[val] = op
+	}
+	return m, nil
+}
+
+// forItem returns the operation of an element of a decoded JSON response.
+func (m *operationMapper) forItem(item any) opencdc.Operation {
+	if m.path == "" {
+		return m.fallback
+	}
+	val, ok := lookupPath(item, m.path)
+	return m.resolve(val, ok && val != nil)
+}
+
+// forPayload returns the operation of a record payload.
+func (m *operationMapper) forPayload(payload opencdc.Data) opencdc.Operation {
+	if m.path == "" {
+		return m.fallback
+	}
+	return m.resolve(lookupPayloadPath(payload, m.path))
+}
+
+// resolve maps the value found at the operation path to an operation. Values
+// that aren't mapped are parsed as operation names (create, update, delete or
+// snapshot), and the fallback operation is used for all other values.
+func (m *operationMapper) resolve(val any, ok bool) opencdc.Operation {
+	if !ok {
+		return m.fallback
+	}
+	str := fmt.Sprint(val)
+	if op, ok := m.mapping[str]; ok {
+		return op
+	}
+	if op, ok := parseOperation(str); ok {
+		return op
+	}
+	return m.fallback
+}
+
+// parseOperation parses the name of an operation, ignoring the case.
+func parseOperation(name string) (opencdc.Operation, bool) {
+	for _, op := range []opencdc.Operation{
+		opencdc.OperationCreate,
+		opencdc.OperationUpdate,
+		opencdc.OperationDelete,
+		opencdc.OperationSnapshot,
+	} {
+		if strings.EqualFold(strings.TrimSpace(name), op.String()) {
+			return op, true
+		}
+	}
+	return 0, false
+}
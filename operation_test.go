@@ -0,0 +1,88 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestOperationMapper(t *testing.T) {
+	is := is.New(t)
+
+	m, err := newOperationMapper(SourceConfig{
+		ResponseOperation:        "update",
+		ResponseOperationPath:    "meta.status",
+		ResponseOperationMapping: []string{"deleted:delete", " active : create"},
+	})
+	is.NoErr(err)
+
+	testCases := []struct {
+		item any
+		want opencdc.Operation
+	}{
+		{item: map[string]any{"meta": map[string]any{"status": "deleted"}}, want: opencdc.OperationDelete},
+		{item: map[string]any{"meta": map[string]any{"status": "active"}}, want: opencdc.OperationCreate},
+		{item: map[string]any{"meta": map[string]any{"status": "Snapshot"}}, want: opencdc.OperationSnapshot},
+		{item: map[string]any{"meta": map[string]any{"status": "unknown"}}, want: opencdc.OperationUpdate},
+		{item: map[string]any{"id": 1}, want: opencdc.OperationUpdate},
+	}
+	for _, tc := range testCases {
+		is.Equal(m.forItem(tc.item), tc.want) // item
+	}
+	is.Equal(m.forPayload(opencdc.RawData(`{"meta":{"status":"deleted"}}`)), opencdc.OperationDelete)
+
+	for _, invalid := range []string{"deleted", ":delete", "deleted:remove"} {
+		_, err = newOperationMapper(SourceConfig{
+			ResponseOperation:        "create",
+			ResponseOperationMapping: []string{invalid},
+		})
+		is.True(err != nil)
+	}
+}
+
+func TestSource_OperationPath(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[{"id":1,"status":"active"},{"id":2,"status":"removed"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                       server.URL,
+		"response.recordsPath":      "items",
+		"response.operationPath":    "status",
+		"response.operationMapping": "active:create,removed:delete",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Operation, opencdc.OperationCreate)
+	rec, err = src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Operation, opencdc.OperationDelete)
+}
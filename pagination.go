@@ -0,0 +1,130 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	paginationModeLink   = "link"
+	paginationModeOffset = "offset"
+)
+
+// hasNextPage returns true if the last response pointed to a next page, which
+// is fetched right away instead of waiting for the next poll.
+func (s *Source) hasNextPage() bool {
+	return s.fetchNextPage
+}
+
+// pollLimitReached returns true if the records fetched since the last poll
+// reached maxRecordsPerPoll, in which case the next page is fetched in the
+// next poll.
+func (s *Source) pollLimitReached() bool {
+	return s.config.MaxRecordsPerPoll > 0 && s.polledRecords >= s.config.MaxRecordsPerPoll
+}
+
+// applyPagination updates the request so that it fetches the current page.
+func (s *Source) applyPagination(reqData *Request) error {
+	switch s.config.PaginationMode {
+	case paginationModeLink:
+		if s.nextPageURL != "" {
+			reqData.URL = s.nextPageURL
+		}
+	case paginationModeOffset:
+		u, err := url.Parse(reqData.URL)
+		if err != nil {
+			return fmt.Errorf("error parsing URL: %w", s.config.redactError(err))
+		}
+		query := u.Query()
+		query.Set(s.config.PaginationOffsetParam, strconv.Itoa(s.offset))
+		query.Set(s.config.PaginationLimitParam, strconv.Itoa(s.config.PaginationPageSize))
+		u.RawQuery = query.Encode()
+		reqData.URL = u.String()
+	}
+	return nil
+}
+
+// updatePagination prepares the request for the next page based on the
+// response of the current page and the records parsed from it.
+func (s *Source) updatePagination(resp *http.Response, records []opencdc.Record) {
+	switch s.config.PaginationMode {
+	case paginationModeLink:
+		s.nextPageURL = nextLinkURL(resp)
+		s.fetchNextPage = s.nextPageURL != ""
+	case paginationModeOffset:
+		// a page that isn't full is the last one, the next poll starts over
+		s.fetchNextPage = len(records) >= s.config.PaginationPageSize
+		if s.fetchNextPage {
+			s.offset += len(records)
+		} else {
+			s.offset = 0
+		}
+	default:
+		// without a pagination mode, the script parsing the response decides
+		// if there's a next page
+		s.fetchNextPage = s.lastHasMore
+	}
+}
+
+// nextLinkURL returns the URL of the link with the relation type "next" in the
+// response's Link headers (RFC 8288), resolved against the request URL. An empty
+// string is returned if there is no such link.
+func nextLinkURL(resp *http.Response) string {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, found := strings.Cut(link, ";")
+			if !found {
+				continue
+			}
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			if !isNextRel(params) {
+				continue
+			}
+
+			u, err := resp.Request.URL.Parse(target[1 : len(target)-1])
+			if err != nil {
+				continue
+			}
+			return u.String()
+		}
+	}
+	return ""
+}
+
+// isNextRel checks if the link parameters contain the relation type "next".
+func isNextRel(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "rel") {
+			continue
+		}
+		rels := strings.Fields(strings.ToLower(strings.Trim(strings.TrimSpace(val), `"`)))
+		if slices.Contains(rels, "next") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/matryer/is"
+)
+
+func TestSource_LinkPagination(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page == "1" {
+			w.Header().Set("Link", `</items?page=2>; rel="next", </items?page=1>; rel="first"`)
+		}
+		fmt.Fprintf(w, "page %s", page)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":             server.URL + "/items",
+		"pagination.mode": "link",
+		// the second page is fetched without waiting for the next poll
+		"pollingPeriod": "1h",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "page 1")
+
+	rec, err = src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "page 2")
+}
+
+func TestSource_MaxRecordsPerPoll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		w.Header().Set("Link", fmt.Sprintf(`</items?page=%d>; rel="next"`, page+1))
+		fmt.Fprintf(w, "page %d", page)
+	}))
+	t.Cleanup(server.Close)
+
+	src := &Source{}
+	err := src.Configure(ctx, map[string]string{
+		"url":               server.URL + "/items",
+		"pagination.mode":   "link",
+		"pollingPeriod":     "1h",
+		"maxRecordsPerPoll": "2",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"page 1", "page 2"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(rec.Payload.After.Bytes()), want)
+	}
+
+	// the limit is reached, the third page is fetched in the next poll
+	readCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = src.Read(readCtx)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+
+	// the next poll continues with the third page
+	src.lastPoll = time.Time{}
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "page 3")
+}
+
+func TestSource_OffsetPagination(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	type item struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	items := []item{{"1", "a"}, {"2", "b"}, {"3", "c"}, {"4", "d"}, {"5", "e"}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("top"))
+		page := items[min(offset, len(items)):min(offset+limit, len(items))]
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := map[string]string{
+		"url":                    server.URL,
+		"pagination.mode":        "offset",
+		"pagination.offsetParam": "skip",
+		"pagination.limitParam":  "top",
+		"pagination.pageSize":    "2",
+		"script.parseResponse":   "./test/parse_response_items.js",
+		"pollingPeriod":          "1ms",
+	}
+
+	src := NewSource()
+	err := src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	var last opencdc.Record
+	for _, want := range []string{"1", "2", "3"} {
+		last, err = src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(last.Key.Bytes()), want)
+	}
+	is.NoErr(src.Teardown(ctx))
+
+	// restarting from the position of the 3rd record continues with the 4th
+	src = NewSource()
+	err = src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, last.Position)
+	is.NoErr(err)
+
+	for _, want := range []string{"4", "5"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(rec.Key.Bytes()), want)
+	}
+
+	// the next page is empty, so the next poll starts over
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, sdk.ErrBackoffRetry))
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Key.Bytes()), "1")
+}
+
+func TestSource_ScriptHasMore(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		requests = append(requests, r.URL.RawQuery)
+		fmt.Fprintf(w, `{"page":%d,"last":%t}`, page, page == 3)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url": server.URL,
+		"script.getRequestData": `function getRequestData(cfg, previousResponse, position) {
+			var req = new Request()
+			req.URL = cfg["url"] + "?page=" + ((previousResponse["page"] || 0) + 1)
+			return req
+		}`,
+		"script.parseResponse": `function parseResponse(bytes, response) {
+			var body = JSON.parse(String.fromCharCode.apply(String, bytes))
+			var rec = new Record()
+			rec.Payload.After = new RawData("page " + body.page)
+			var resp = new Response()
+			resp.Records = [rec]
+			resp.CustomData = {page: body.last ? 0 : body.page}
+			resp.HasMore = !body.last
+			return resp
+		}`,
+		// the following pages are fetched without waiting for the next poll
+		"pollingPeriod": "1h",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"page 1", "page 2", "page 3"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(rec.Payload.After.Bytes()), want)
+	}
+
+	// the last page doesn't have more, the next request waits for the next poll
+	readCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = src.Read(readCtx)
+	is.True(errors.Is(err, context.DeadlineExceeded))
+	is.Equal(requests, []string{"page=1", "page=2", "page=3"})
+}
+
+func TestSource_OffsetPaginationRequiresParser(t *testing.T) {
+	is := is.New(t)
+
+	src := NewSource()
+	err := src.Configure(context.Background(), map[string]string{
+		"url":             "http://localhost:8082/resource",
+		"pagination.mode": "offset",
+	})
+	is.True(err != nil)
+}
+
+func TestNextLinkURL(t *testing.T) {
+	reqURL, _ := url.Parse("https://example.com/api/items?page=1")
+
+	testCases := []struct {
+		name   string
+		header []string
+		want   string
+	}{
+		{
+			name:   "no header",
+			header: nil,
+			want:   "",
+		},
+		{
+			name:   "absolute URL",
+			header: []string{`<https://example.com/api/items?page=2>; rel="next"`},
+			want:   "https://example.com/api/items?page=2",
+		},
+		{
+			name:   "relative URL among other links",
+			header: []string{`</api/items?page=1>; rel="prev", </api/items?page=3>; rel="next"`},
+			want:   "https://example.com/api/items?page=3",
+		},
+		{
+			name:   "multiple relation types in separate headers",
+			header: []string{`<?page=9>; rel="last"`, `<?page=2>; title="next page"; rel="next last"`},
+			want:   "https://example.com/api/items?page=2",
+		},
+		{
+			name:   "no next link",
+			header: []string{`<?page=9>; rel="last"`},
+			want:   "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			resp := &http.Response{
+				Header:  http.Header{"Link": tc.header},
+				Request: &http.Request{URL: reqURL},
+			}
+			is.Equal(nextLinkURL(resp), tc.want)
+		})
+	}
+}
@@ -8,41 +8,467 @@ import (
 )
 
 const (
-	DestinationConfigHeaders = "headers"
-	DestinationConfigMethod  = "method"
-	DestinationConfigParams  = "params.*"
-	DestinationConfigUrl     = "url"
+	DestinationConfigAuthApiKeyHeader             = "auth.apiKey.header"
+	DestinationConfigAuthApiKeyQueryParam         = "auth.apiKey.queryParam"
+	DestinationConfigAuthApiKeyValue              = "auth.apiKey.value"
+	DestinationConfigAuthBasicPassword            = "auth.basic.password"
+	DestinationConfigAuthBasicUsername            = "auth.basic.username"
+	DestinationConfigAuthOauth2ClientID           = "auth.oauth2.clientID"
+	DestinationConfigAuthOauth2ClientSecret       = "auth.oauth2.clientSecret"
+	DestinationConfigAuthOauth2ExtraParams        = "auth.oauth2.extraParams.*"
+	DestinationConfigAuthOauth2RefreshToken       = "auth.oauth2.refreshToken"
+	DestinationConfigAuthOauth2Scopes             = "auth.oauth2.scopes"
+	DestinationConfigAuthOauth2TokenURL           = "auth.oauth2.tokenURL"
+	DestinationConfigBodyEncoding                 = "bodyEncoding"
+	DestinationConfigCaptureResponse              = "captureResponse"
+	DestinationConfigCompression                  = "compression"
+	DestinationConfigConcurrency                  = "concurrency"
+	DestinationConfigContentType                  = "contentType"
+	DestinationConfigCookies                      = "cookies"
+	DestinationConfigExpectedStatus               = "expectedStatus"
+	DestinationConfigFollowRedirects              = "followRedirects"
+	DestinationConfigFormRawField                 = "form.rawField"
+	DestinationConfigGraphqlMutation              = "graphql.mutation"
+	DestinationConfigGraphqlVariables             = "graphql.variables"
+	DestinationConfigHeaders                      = "headers"
+	DestinationConfigHeadersMap                   = "headersMap.*"
+	DestinationConfigHttp2                        = "http2"
+	DestinationConfigIdempotencyKey               = "idempotencyKey"
+	DestinationConfigKeyHeader                    = "keyHeader"
+	DestinationConfigLogMaxBodyBytes              = "logMaxBodyBytes"
+	DestinationConfigLogRedactQuery               = "logRedactQuery"
+	DestinationConfigLogRequests                  = "logRequests"
+	DestinationConfigLogResponses                 = "logResponses"
+	DestinationConfigMaxRedirects                 = "maxRedirects"
+	DestinationConfigMethod                       = "method"
+	DestinationConfigMetricsEnabled               = "metrics.enabled"
+	DestinationConfigMultipartFileField           = "multipart.fileField"
+	DestinationConfigMultipartFileName            = "multipart.fileName"
+	DestinationConfigMultipartMetadataFields      = "multipart.metadataFields"
+	DestinationConfigOnEmptyPayload               = "onEmptyPayload"
+	DestinationConfigOnError                      = "onError"
+	DestinationConfigParams                       = "params.*"
+	DestinationConfigParamsSeparator              = "paramsSeparator"
+	DestinationConfigRateLimitBurst               = "rateLimit.burst"
+	DestinationConfigRateLimitPerHost             = "rateLimit.perHost"
+	DestinationConfigRateLimitPerSecond           = "rateLimit.perSecond"
+	DestinationConfigRedactHeaders                = "redact.headers"
+	DestinationConfigRedactQueryParams            = "redact.queryParams"
+	DestinationConfigRequestTimeout               = "requestTimeout"
+	DestinationConfigRetryBackoff                 = "retry.backoff"
+	DestinationConfigRetryBackoffFactor           = "retry.backoffFactor"
+	DestinationConfigRetryMaxAttempts             = "retry.maxAttempts"
+	DestinationConfigRetryStatusCodes             = "retry.statusCodes"
+	DestinationConfigStreaming                    = "streaming"
+	DestinationConfigTlsCaCert                    = "tls.caCert"
+	DestinationConfigTlsClientCert                = "tls.clientCert"
+	DestinationConfigTlsClientKey                 = "tls.clientKey"
+	DestinationConfigTlsInsecureSkipVerify        = "tls.insecureSkipVerify"
+	DestinationConfigTransportIdleConnTimeout     = "transport.idleConnTimeout"
+	DestinationConfigTransportMaxIdleConns        = "transport.maxIdleConns"
+	DestinationConfigTransportMaxIdleConnsPerHost = "transport.maxIdleConnsPerHost"
+	DestinationConfigUrl                          = "url"
+	DestinationConfigValidateConnection           = "validateConnection"
+	DestinationConfigValidateConnectionMethod     = "validateConnection.method"
 )
 
 func (DestinationConfig) Parameters() map[string]config.Parameter {
 	return map[string]config.Parameter{
+		DestinationConfigAuthApiKeyHeader: {
+			Default:     "",
+			Description: "Name of the header containing the API key, e.g. `X-API-Key`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthApiKeyQueryParam: {
+			Default:     "",
+			Description: "Name of the query parameter containing the API key, e.g. `api_key`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthApiKeyValue: {
+			Default:     "",
+			Description: "API key sent with every request, in the header auth.apiKey.header or in the query\nparameter auth.apiKey.queryParam. The key is redacted in logs and error messages.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthBasicPassword: {
+			Default:     "",
+			Description: "Password used for HTTP Basic Authentication, requires auth.basic.username to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthBasicUsername: {
+			Default:     "",
+			Description: "Username used for HTTP Basic Authentication, requires auth.basic.password to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthOauth2ClientID: {
+			Default:     "",
+			Description: "Client ID used to obtain OAuth2 access tokens.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthOauth2ClientSecret: {
+			Default:     "",
+			Description: "Client secret used to obtain OAuth2 access tokens.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthOauth2ExtraParams: {
+			Default:     "",
+			Description: "Additional parameters sent in the body of OAuth2 client credentials token requests, use\nauth.oauth2.extraParams.* as the config key and specify its value, e.g. set\n`auth.oauth2.extraParams.audience` to `https://api.example.com`. Can't be used with\nauth.oauth2.refreshToken.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthOauth2RefreshToken: {
+			Default:     "",
+			Description: "Refresh token used to obtain OAuth2 access tokens with the refresh token flow instead\nof the client credentials flow. If the server rotates the refresh token, the new one\nis used for the following refreshes for as long as the connector is running, after a\nrestart the configured token is used again.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthOauth2Scopes: {
+			Default:     "",
+			Description: "Scopes requested in the OAuth2 client credentials flow, comma separated list.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigAuthOauth2TokenURL: {
+			Default:     "",
+			Description: "URL of the OAuth2 token endpoint. When set, the connector obtains an access token\nusing the OAuth2 client credentials flow, or the refresh token flow if\nauth.oauth2.refreshToken is set, and refreshes it before it expires.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigBodyEncoding: {
+			Default:     "json",
+			Description: "Encoding of structured payloads sent as the request body. With `json`, the payload is\nencoded as JSON. With `cbor` or `msgpack`, it's encoded as CBOR or MessagePack and the\n`Content-Type` is `application/cbor` or `application/x-msgpack`, unless it's set in\ncontentType or headers. Map keys are sorted with all encodings. Raw payloads are always\nsent as is. Can't be combined with contentType multipart or form, or graphql.mutation.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"json", "cbor", "msgpack"}},
+			},
+		},
+		DestinationConfigCaptureResponse: {
+			Default:     "false",
+			Description: "Whether the body of successful responses is logged together with the key and position of\nthe record, e.g. to capture IDs assigned by the server. The body is truncated to\nlogMaxBodyBytes.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigCompression: {
+			Default:     "",
+			Description: "Compression applied to the request body, the `Content-Encoding` header is set\naccordingly. When empty, the body is sent uncompressed.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"gzip"}},
+			},
+		},
+		DestinationConfigConcurrency: {
+			Default:     "1",
+			Description: "Maximum number of requests sent in parallel. With a value greater than 1, the\nrecords of a batch may reach the server in a different order than they were written.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		DestinationConfigContentType: {
+			Default:     "",
+			Description: "Encoding of the request body. With `multipart`, the body is sent as `multipart/form-data`,\nwith a file part containing the record payload and optional parts containing metadata.\nWith `form`, the body is sent as `application/x-www-form-urlencoded`, where each field of\na structured payload becomes a form field, lists become repeated fields and nested objects\nare encoded as JSON. A raw payload is sent as a single field named after form.rawField.\nIn both cases the `Content-Type` header is set automatically. Any other value is a media\ntype, e.g. `application/xml; charset=utf-8`, sent as the `Content-Type` of the payload\nunless the header is set in headers. When empty, the type is inferred from the payload:\nstructured payloads and raw payloads containing valid JSON are sent as `application/json`,\nother raw payloads are sniffed, e.g. `text/plain; charset=utf-8` for text and\n`application/octet-stream` for binary data.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigCookies: {
+			Default:     "false",
+			Description: "Whether cookies set by the server are stored and sent with subsequent requests,\nincluding the ones following the connection test. Cookies are kept in memory\nfor as long as the connector is running.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigExpectedStatus: {
+			Default:     "200-399",
+			Description: "Response status codes that are considered successful, as a list of codes or ranges,\ne.g. `200-299,404`. Responses with other status codes result in an error.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigFollowRedirects: {
+			Default:     "true",
+			Description: "Whether redirect responses are followed. When disabled, a redirect response is\nreturned as-is and checked against expectedStatus.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigFormRawField: {
+			Default:     "payload",
+			Description: "Name of the form field containing a raw payload, used with contentType form.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigGraphqlMutation: {
+			Default:     "",
+			Description: "GraphQL mutation sent for each record, using Go templates with the same data and\nfunctions as url. When set, the request body contains the mutation instead of the\nrecord payload, and errors in the GraphQL response are treated as failures, even\nwith the status 200 OK. Requires the method POST.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigGraphqlVariables: {
+			Default:     "",
+			Description: "Variables of the GraphQL mutation, using Go templates with the same data and functions\nas url. The result needs to be a JSON object, e.g. `{\"input\": {{ toJson .Payload.After }}}`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		DestinationConfigHeaders: {
 			Default:     "",
-			Description: "Http headers to use in the request, comma separated list of : separated pairs",
+			Description: "Http headers to use in the request, comma separated list of : separated pairs. The value is\neverything after the first colon, e.g. `Authorization:Bearer x:y`, and a header that's\nlisted multiple times gets all the values.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		DestinationConfigHeadersMap: {
+			Default:     "",
+			Description: "Http headers to use in the request, use headersMap.* as the config key and specify its\nvalue, e.g. set `headersMap.Authorization` to `Bearer x:y`. Merged with headers, which\nis convenient for values containing commas.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigHttp2: {
+			Default:     "false",
+			Description: "Whether requests are sent using HTTP/2 only. Requests to https urls negotiate HTTP/2\nduring the TLS handshake, requests to http urls use HTTP/2 over cleartext (h2c) with\nprior knowledge. Proxies aren't supported in this mode. When disabled, HTTP/2 is used\nif the server supports it over TLS, otherwise HTTP/1.1.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigIdempotencyKey: {
+			Default:     "",
+			Description: "Name of the header containing an idempotency key, e.g. `Idempotency-Key`. The key is\nderived from the record's key and position, so it's the same when a request is retried\nor a record is written again, which lets the server discard duplicates. When empty, no\nidempotency key is sent.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigKeyHeader: {
+			Default:     "",
+			Description: "Name of the header containing the record key, e.g. `X-Record-Key`. A raw key is sent as is,\na structured key is sent as JSON. When empty, or when the record has no key, the header\nisn't sent.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigLogMaxBodyBytes: {
+			Default:     "1024",
+			Description: "Maximum number of bytes of a request or response body that are logged.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigLogRedactQuery: {
+			Default:     "false",
+			Description: "Whether the query of the URL is redacted in logged requests.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigLogRequests: {
+			Default:     "false",
+			Description: "Whether the sent requests (method, URL, headers and body) are logged at trace level,\nfor debugging. The values of the redact.headers and redact.queryParams and the\nconfigured credentials are redacted. OAuth2 token requests are not logged.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigLogResponses: {
+			Default:     "false",
+			Description: "Whether the received responses (status, duration, headers and body) are logged at trace\nlevel, for debugging. The values of the redact.headers and the configured credentials\nare redacted. Responses to OAuth2 token requests are not logged.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigMaxRedirects: {
+			Default:     "10",
+			Description: "Maximum number of redirects followed for a single request.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
 		DestinationConfigMethod: {
 			Default:     "POST",
-			Description: "Http method to use in the request",
+			Description: "Http method to use in the request. The request body is the same for all methods, so a\nDELETE request contains the payload of the record, if it has one.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
 				config.ValidationInclusion{List: []string{"POST", "PUT", "DELETE", "PATCH"}},
 			},
 		},
+		DestinationConfigMetricsEnabled: {
+			Default:     "false",
+			Description: "Whether metrics about the sent requests (counts by status code and method, and a\nhistogram of durations) are recorded. The metrics are published using expvar under\nthe name `conduit_connector_http`.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigMultipartFileField: {
+			Default:     "file",
+			Description: "Name of the form field containing the record payload, used with contentType multipart.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigMultipartFileName: {
+			Default:     "payload",
+			Description: "File name of the part containing the record payload, used with contentType multipart.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigMultipartMetadataFields: {
+			Default:     "",
+			Description: "Metadata fields sent as additional parts with contentType multipart, as a comma separated\nlist of metadata keys. A key can be followed by `:` and the name of the form field, e.g.\n`file.name:filename`, otherwise the key is used as the name. Fields missing in the record\nmetadata are skipped.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigOnEmptyPayload: {
+			Default:     "send",
+			Description: "What happens with a record without a payload (`Payload.After`) when using a method that\nrequires a body (POST, PUT or PATCH). With `send`, the request is sent without a body.\nWith `skip`, the record is logged and skipped. With `error`, the write fails.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"send", "skip", "error"}},
+			},
+		},
+		DestinationConfigOnError: {
+			Default:     "fail",
+			Description: "What happens when the server rejects a record by responding with an unexpected status,\nafter all retries. With `fail`, the write fails and the pipeline stops. With `skip`, the\nerror is logged and the record is skipped. Other errors, e.g. network errors, always fail\nthe write.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fail", "skip"}},
+			},
+		},
 		DestinationConfigParams: {
 			Default:     "",
 			Description: "parameters to use in the request, use params.* as the config key and specify its value, ex: set \"params.id\" as \"1\".",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		DestinationConfigParamsSeparator: {
+			Default:     "",
+			Description: "Separator used to split param values into multiple query parameters with the same\nname, e.g. with `,` the param `params.tag` set to `a,b` results in `tag=a&tag=b`.\nWhen empty, param values aren't split.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRateLimitBurst: {
+			Default:     "1",
+			Description: "Maximum number of requests sent at once when the rate limit allows it, e.g. after\nthe connector was idle, used with rateLimit.perSecond.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		DestinationConfigRateLimitPerHost: {
+			Default:     "false",
+			Description: "Whether the rate limit applies to each host separately instead of to all requests of\nthe connector. The limit of a host is shared by all connectors in the same process\nthat enable this option, so the aggregate rate of requests to the host stays within\nit, regardless of the urls. If the connectors configure different limits, the most\nrestrictive one applies.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRateLimitPerSecond: {
+			Default:     "0",
+			Description: "Maximum number of requests sent per second on average, including retries, the\nrequests for further pages and the requests sent in parallel. Requests wait until\nthey're allowed to be sent. Zero means no limit.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRedactHeaders: {
+			Default:     "Authorization,Proxy-Authorization,Cookie,Set-Cookie",
+			Description: "Names of headers whose values are replaced with `***` in logs, comma separated list.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRedactQueryParams: {
+			Default:     "api_key,token",
+			Description: "Names of query parameters whose values are replaced with `***` in logs and error\nmessages, comma separated list.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRequestTimeout: {
+			Default:     "30s",
+			Description: "Maximum time a single request can take, including reading the response body.\nZero means no timeout.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRetryBackoff: {
+			Default:     "1s",
+			Description: "Delay before retrying a failed request for the first time. The Retry-After response\nheader takes precedence when present, a delay longer than 5 minutes is shortened.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRetryBackoffFactor: {
+			Default:     "2",
+			Description: "Factor by which the delay is multiplied after each retry, needs to be at least 1.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigRetryMaxAttempts: {
+			Default:     "3",
+			Description: "Maximum number of attempts for a request that fails with a transient network error (a\ntimeout, or a refused, reset or closed connection) or with one of the retry.statusCodes,\nincluding the first attempt. Network errors are only retried for GET, HEAD, OPTIONS and\nTRACE requests, and for requests of the destination with an idempotencyKey, since other\nrequests might have been processed by the server already.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		DestinationConfigRetryStatusCodes: {
+			Default:     "429,502,503,504",
+			Description: "Response status codes for which the request is retried, comma separated list. Other unexpected status codes are treated as fatal and are not retried.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigStreaming: {
+			Default:     "false",
+			Description: "Whether the request body is streamed using chunked transfer encoding, without a\nContent-Length header. With compression, the body is compressed while it's sent,\nwhich avoids holding a compressed copy of large payloads in memory.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigTlsCaCert: {
+			Default:     "",
+			Description: "CA certificate used to verify the server's certificate, either the path to a\nPEM encoded file or the PEM encoded certificate itself. When not set, the\nsystem's root CAs are used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigTlsClientCert: {
+			Default:     "",
+			Description: "Path to a PEM encoded client certificate used for mutual TLS, requires tls.clientKey to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigTlsClientKey: {
+			Default:     "",
+			Description: "Path to the PEM encoded private key of the client certificate, requires tls.clientCert to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigTlsInsecureSkipVerify: {
+			Default:     "false",
+			Description: "Skip verifying the server's certificate chain and host name. This should only\nbe used for testing, as it makes the connection vulnerable to man-in-the-middle attacks.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigTransportIdleConnTimeout: {
+			Default:     "90s",
+			Description: "Time after which an idle (keep-alive) connection is closed. Zero means no limit.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigTransportMaxIdleConns: {
+			Default:     "100",
+			Description: "Maximum number of idle (keep-alive) connections across all hosts. Zero means no limit.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		DestinationConfigTransportMaxIdleConnsPerHost: {
+			Default:     "2",
+			Description: "Maximum number of idle (keep-alive) connections kept per host. Raising it lets\nconcurrent requests reuse connections instead of opening new ones.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
 		DestinationConfigUrl: {
 			Default:     "",
-			Description: "URL is a Go template expression for the URL used in the HTTP request, using Go [templates](https://pkg.go.dev/text/template).\nThe value provided to the template is [opencdc.Record](https://github.com/ConduitIO/conduit-connector-sdk/blob/bfc1d83eb75460564fde8cb4f8f96318f30bd1b4/record.go#L81),\nso the template has access to all its fields (e.g. .Position, .Key, .Metadata, and so on). We also inject all template functions provided by [sprig](https://masterminds.github.io/sprig/)\nto make it easier to write templates.",
+			Description: "URL is a Go template expression for the URL used in the HTTP request, using Go [templates](https://pkg.go.dev/text/template).\nThe value provided to the template is [opencdc.Record](https://github.com/ConduitIO/conduit-connector-sdk/blob/bfc1d83eb75460564fde8cb4f8f96318f30bd1b4/record.go#L81),\nso the template has access to all its fields (e.g. .Position, .Key, .Metadata, and so on). We also inject all template functions provided by [sprig](https://masterminds.github.io/sprig/)\nto make it easier to write templates. Values used as a path segment or as a query parameter should be\nescaped using `pathEscape` or `queryEscape`, e.g. `/items/{{ .Payload.After.id | pathEscape }}`, so that\ncharacters like `/` or `&` don't change the URL. The record key and payload are `opencdc.Data`, use\n`dataString` to render them as a string, e.g. `/items/{{ dataString .Key | pathEscape }}`.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
 				config.ValidationRequired{},
 			},
 		},
+		DestinationConfigValidateConnection: {
+			Default:     "true",
+			Description: "Whether a request is sent to check the connection when the connector starts.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigValidateConnectionMethod: {
+			Default:     "HEAD",
+			Description: "Http method of the request sent to check the connection when the connector starts.\nThe request is sent without a body.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"HEAD", "GET", "OPTIONS"}},
+			},
+		},
 	}
 }
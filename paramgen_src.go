@@ -8,29 +8,369 @@ import (
 )
 
 const (
-	SourceConfigHeaders              = "headers"
-	SourceConfigMethod               = "method"
-	SourceConfigParams               = "params.*"
-	SourceConfigPollingPeriod        = "pollingPeriod"
-	SourceConfigScriptGetRequestData = "script.getRequestData"
-	SourceConfigScriptParseResponse  = "script.parseResponse"
-	SourceConfigUrl                  = "url"
+	SourceConfigAcceptHeader                        = "acceptHeader"
+	SourceConfigAuthApiKeyHeader                    = "auth.apiKey.header"
+	SourceConfigAuthApiKeyQueryParam                = "auth.apiKey.queryParam"
+	SourceConfigAuthApiKeyValue                     = "auth.apiKey.value"
+	SourceConfigAuthBasicPassword                   = "auth.basic.password"
+	SourceConfigAuthBasicUsername                   = "auth.basic.username"
+	SourceConfigAuthOauth2ClientID                  = "auth.oauth2.clientID"
+	SourceConfigAuthOauth2ClientSecret              = "auth.oauth2.clientSecret"
+	SourceConfigAuthOauth2ExtraParams               = "auth.oauth2.extraParams.*"
+	SourceConfigAuthOauth2RefreshToken              = "auth.oauth2.refreshToken"
+	SourceConfigAuthOauth2Scopes                    = "auth.oauth2.scopes"
+	SourceConfigAuthOauth2TokenURL                  = "auth.oauth2.tokenURL"
+	SourceConfigCompression                         = "compression"
+	SourceConfigConditionalRequests                 = "conditionalRequests"
+	SourceConfigConditionalRequestsLastModifiedFrom = "conditionalRequests.lastModifiedFrom"
+	SourceConfigCookies                             = "cookies"
+	SourceConfigCsvDelimiter                        = "csv.delimiter"
+	SourceConfigCsvHasHeader                        = "csv.hasHeader"
+	SourceConfigEmitHeartbeat                       = "emitHeartbeat"
+	SourceConfigExpectedStatus                      = "expectedStatus"
+	SourceConfigFollowRedirects                     = "followRedirects"
+	SourceConfigGraphqlDataPath                     = "graphql.dataPath"
+	SourceConfigGraphqlQuery                        = "graphql.query"
+	SourceConfigGraphqlVariables                    = "graphql.variables"
+	SourceConfigHeaders                             = "headers"
+	SourceConfigHeadersMap                          = "headersMap.*"
+	SourceConfigHttp2                               = "http2"
+	SourceConfigLogMaxBodyBytes                     = "logMaxBodyBytes"
+	SourceConfigLogRedactQuery                      = "logRedactQuery"
+	SourceConfigLogRequests                         = "logRequests"
+	SourceConfigLogResponses                        = "logResponses"
+	SourceConfigLongPollMaxIdle                     = "longPoll.maxIdle"
+	SourceConfigMaxRecordsPerPoll                   = "maxRecordsPerPoll"
+	SourceConfigMaxRedirects                        = "maxRedirects"
+	SourceConfigMaxResponseBytes                    = "maxResponseBytes"
+	SourceConfigMethod                              = "method"
+	SourceConfigMetricsEnabled                      = "metrics.enabled"
+	SourceConfigMode                                = "mode"
+	SourceConfigPaginationLimitParam                = "pagination.limitParam"
+	SourceConfigPaginationMode                      = "pagination.mode"
+	SourceConfigPaginationOffsetParam               = "pagination.offsetParam"
+	SourceConfigPaginationPageSize                  = "pagination.pageSize"
+	SourceConfigParams                              = "params.*"
+	SourceConfigParamsSeparator                     = "paramsSeparator"
+	SourceConfigPollingJitter                       = "pollingJitter"
+	SourceConfigPollingPeriod                       = "pollingPeriod"
+	SourceConfigPollingPeriodAdaptive               = "pollingPeriod.adaptive"
+	SourceConfigPollingPeriodMax                    = "pollingPeriod.max"
+	SourceConfigPollingPeriodMin                    = "pollingPeriod.min"
+	SourceConfigRateLimitBurst                      = "rateLimit.burst"
+	SourceConfigRateLimitPerHost                    = "rateLimit.perHost"
+	SourceConfigRateLimitPerSecond                  = "rateLimit.perSecond"
+	SourceConfigRedactHeaders                       = "redact.headers"
+	SourceConfigRedactQueryParams                   = "redact.queryParams"
+	SourceConfigRequestBody                         = "requestBody"
+	SourceConfigRequestTimeout                      = "requestTimeout"
+	SourceConfigResponseCursorPath                  = "response.cursorPath"
+	SourceConfigResponseHeaderPrefix                = "response.headerPrefix"
+	SourceConfigResponseKeyPath                     = "response.keyPath"
+	SourceConfigResponseKeyPaths                    = "response.keyPaths"
+	SourceConfigResponseMetadataHeaders             = "response.metadataHeaders"
+	SourceConfigResponseOperation                   = "response.operation"
+	SourceConfigResponseOperationMapping            = "response.operationMapping"
+	SourceConfigResponseOperationPath               = "response.operationPath"
+	SourceConfigResponseProtobufDescriptorSet       = "response.protobuf.descriptorSet"
+	SourceConfigResponseProtobufMessage             = "response.protobuf.message"
+	SourceConfigResponseRecordsPath                 = "response.recordsPath"
+	SourceConfigResponseRecordsPaths                = "response.recordsPaths"
+	SourceConfigResponseUseNumber                   = "response.useNumber"
+	SourceConfigResponseFormat                      = "responseFormat"
+	SourceConfigRetryBackoff                        = "retry.backoff"
+	SourceConfigRetryBackoffFactor                  = "retry.backoffFactor"
+	SourceConfigRetryMaxAttempts                    = "retry.maxAttempts"
+	SourceConfigRetryStatusCodes                    = "retry.statusCodes"
+	SourceConfigScriptGetRequestData                = "script.getRequestData"
+	SourceConfigScriptParseResponse                 = "script.parseResponse"
+	SourceConfigScriptTimeout                       = "script.timeout"
+	SourceConfigStartPosition                       = "startPosition"
+	SourceConfigTlsCaCert                           = "tls.caCert"
+	SourceConfigTlsClientCert                       = "tls.clientCert"
+	SourceConfigTlsClientKey                        = "tls.clientKey"
+	SourceConfigTlsInsecureSkipVerify               = "tls.insecureSkipVerify"
+	SourceConfigTransportIdleConnTimeout            = "transport.idleConnTimeout"
+	SourceConfigTransportMaxIdleConns               = "transport.maxIdleConns"
+	SourceConfigTransportMaxIdleConnsPerHost        = "transport.maxIdleConnsPerHost"
+	SourceConfigUrl                                 = "url"
+	SourceConfigUrls                                = "urls"
+	SourceConfigValidateConnection                  = "validateConnection"
+	SourceConfigValidateConnectionMethod            = "validateConnection.method"
+	SourceConfigValidateConnectionPreflight         = "validateConnection.preflight"
+	SourceConfigValidateConnectionUrl               = "validateConnection.url"
+	SourceConfigWebhookListenAddr                   = "webhook.listenAddr"
+	SourceConfigWebhookPath                         = "webhook.path"
+	SourceConfigWebhookSecret                       = "webhook.secret"
+	SourceConfigWebhookSecretHeader                 = "webhook.secretHeader"
 )
 
 func (SourceConfig) Parameters() map[string]config.Parameter {
 	return map[string]config.Parameter{
+		SourceConfigAcceptHeader: {
+			Default:     "",
+			Description: "Value of the Accept header sent with requests, unless the header is set in headers.\nDefaults to `application/json` when the response is parsed as JSON (response.recordsPath\nor graphql.query), to `application/xml` with the `xml` format and to\n`application/x-ndjson, application/json` with the `ndjson` format and to\n`application/x-protobuf` with the `protobuf` format and to `text/csv` with the `csv` format,\notherwise no Accept header is sent.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthApiKeyHeader: {
+			Default:     "",
+			Description: "Name of the header containing the API key, e.g. `X-API-Key`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthApiKeyQueryParam: {
+			Default:     "",
+			Description: "Name of the query parameter containing the API key, e.g. `api_key`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthApiKeyValue: {
+			Default:     "",
+			Description: "API key sent with every request, in the header auth.apiKey.header or in the query\nparameter auth.apiKey.queryParam. The key is redacted in logs and error messages.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthBasicPassword: {
+			Default:     "",
+			Description: "Password used for HTTP Basic Authentication, requires auth.basic.username to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthBasicUsername: {
+			Default:     "",
+			Description: "Username used for HTTP Basic Authentication, requires auth.basic.password to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthOauth2ClientID: {
+			Default:     "",
+			Description: "Client ID used to obtain OAuth2 access tokens.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthOauth2ClientSecret: {
+			Default:     "",
+			Description: "Client secret used to obtain OAuth2 access tokens.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthOauth2ExtraParams: {
+			Default:     "",
+			Description: "Additional parameters sent in the body of OAuth2 client credentials token requests, use\nauth.oauth2.extraParams.* as the config key and specify its value, e.g. set\n`auth.oauth2.extraParams.audience` to `https://api.example.com`. Can't be used with\nauth.oauth2.refreshToken.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthOauth2RefreshToken: {
+			Default:     "",
+			Description: "Refresh token used to obtain OAuth2 access tokens with the refresh token flow instead\nof the client credentials flow. If the server rotates the refresh token, the new one\nis used for the following refreshes for as long as the connector is running, after a\nrestart the configured token is used again.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthOauth2Scopes: {
+			Default:     "",
+			Description: "Scopes requested in the OAuth2 client credentials flow, comma separated list.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigAuthOauth2TokenURL: {
+			Default:     "",
+			Description: "URL of the OAuth2 token endpoint. When set, the connector obtains an access token\nusing the OAuth2 client credentials flow, or the refresh token flow if\nauth.oauth2.refreshToken is set, and refreshes it before it expires.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigCompression: {
+			Default:     "",
+			Description: "Compression of the file contained in the response body, e.g. a report downloaded as a\n`.csv.gz` attachment. With `gzip`, a body containing gzip data is decompressed before it's\nparsed according to responseFormat, otherwise it's used as is. This is independent of the\n`Content-Encoding` header, bodies compressed using the header are always decompressed.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"gzip"}},
+			},
+		},
+		SourceConfigConditionalRequests: {
+			Default:     "false",
+			Description: "Whether the `ETag` of a response is sent in the `If-None-Match` header of the next\npoll, so that the server can reply with `304 Not Modified` if nothing changed.\nThe ETag is stored in the record positions.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigConditionalRequestsLastModifiedFrom: {
+			Default:     "",
+			Description: "Where the time sent in the `If-Modified-Since` header of conditional requests comes\nfrom. With `header`, it's the `Last-Modified` header of the previous response. With\n`position`, it's the position of the last record, and with `metadata:<key>` (e.g.\n`metadata:updatedAt`) the metadata field of the last record. The value can be an HTTP\ndate, an RFC 3339 timestamp or a Unix timestamp in seconds, as well as the position of\na record without a position of its own (`unix-<nanoseconds>`). When empty, only the\n`ETag` is used. Requires conditionalRequests.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigCookies: {
+			Default:     "false",
+			Description: "Whether cookies set by the server are stored and sent with subsequent requests,\nincluding the ones following the connection test. Cookies are kept in memory\nfor as long as the connector is running.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigCsvDelimiter: {
+			Default:     ",",
+			Description: "Delimiter between the fields of a CSV response, used with responseFormat csv. Needs to\nbe a single character, e.g. `;`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigCsvHasHeader: {
+			Default:     "true",
+			Description: "Whether the first row of a CSV response contains the column names, used with\nresponseFormat csv. The names are used as the keys of the records, otherwise the keys\nare the indexes of the columns, starting at `0`.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigEmitHeartbeat: {
+			Default:     "false",
+			Description: "Whether a heartbeat is logged at info level after each poll that returns no records,\nto tell an idle source apart from a stuck one.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigExpectedStatus: {
+			Default:     "200-299",
+			Description: "Response status codes that are considered successful, as a list of codes or ranges,\ne.g. `200-299,304`. Responses with other status codes result in an error.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigFollowRedirects: {
+			Default:     "true",
+			Description: "Whether redirect responses are followed. When disabled, a redirect response is\nreturned as-is and checked against expectedStatus.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigGraphqlDataPath: {
+			Default:     "",
+			Description: "Path to the array of records within the data of a GraphQL response, e.g.\n`users.nodes`. Required with graphql.query. response.keyPath and response.cursorPath\ncan be used to extract the key of each record and a cursor from the response.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigGraphqlQuery: {
+			Default:     "",
+			Description: "GraphQL query sent to the url. When set, the query is sent in a POST request, and\nthe records are extracted from the response data using graphql.dataPath.\nErrors in the response are returned as connector errors.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigGraphqlVariables: {
+			Default:     "",
+			Description: "Variables of the GraphQL query, as a JSON object. The variables can be a Go template,\nevaluated before each request with the same data and functions as url, e.g.\n`{\"since\": {{ .cursor | default \"\" | toJson }}}` to pass the cursor extracted using\nresponse.cursorPath.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		SourceConfigHeaders: {
 			Default:     "",
-			Description: "Http headers to use in the request, comma separated list of : separated pairs",
+			Description: "Http headers to use in the request, comma separated list of : separated pairs. The value is\neverything after the first colon, e.g. `Authorization:Bearer x:y`, and a header that's\nlisted multiple times gets all the values.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		SourceConfigHeadersMap: {
+			Default:     "",
+			Description: "Http headers to use in the request, use headersMap.* as the config key and specify its\nvalue, e.g. set `headersMap.Authorization` to `Bearer x:y`. Merged with headers, which\nis convenient for values containing commas.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigHttp2: {
+			Default:     "false",
+			Description: "Whether requests are sent using HTTP/2 only. Requests to https urls negotiate HTTP/2\nduring the TLS handshake, requests to http urls use HTTP/2 over cleartext (h2c) with\nprior knowledge. Proxies aren't supported in this mode. When disabled, HTTP/2 is used\nif the server supports it over TLS, otherwise HTTP/1.1.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigLogMaxBodyBytes: {
+			Default:     "1024",
+			Description: "Maximum number of bytes of a request or response body that are logged.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		SourceConfigLogRedactQuery: {
+			Default:     "false",
+			Description: "Whether the query of the URL is redacted in logged requests.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigLogRequests: {
+			Default:     "false",
+			Description: "Whether the sent requests (method, URL, headers and body) are logged at trace level,\nfor debugging. The values of the redact.headers and redact.queryParams and the\nconfigured credentials are redacted. OAuth2 token requests are not logged.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigLogResponses: {
+			Default:     "false",
+			Description: "Whether the received responses (status, duration, headers and body) are logged at trace\nlevel, for debugging. The values of the redact.headers and the configured credentials\nare redacted. Responses to OAuth2 token requests are not logged.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigLongPollMaxIdle: {
+			Default:     "90s",
+			Description: "Maximum time a request is held open in the `longpoll` mode. A request that doesn't\nreturn within this time is considered to contain no data and is sent again.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigMaxRecordsPerPoll: {
+			Default:     "0",
+			Description: "Maximum number of records fetched in one poll when following pagination. The limit\nis checked after each page, once it's reached the remaining pages are fetched in the\nnext poll, starting from the next page. Zero means no limit.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		SourceConfigMaxRedirects: {
+			Default:     "10",
+			Description: "Maximum number of redirects followed for a single request.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		SourceConfigMaxResponseBytes: {
+			Default:     "52428800",
+			Description: "Maximum size of a response body in bytes, after decompression. A larger response\nresults in an error, instead of being truncated. Only the records of one response are\nbuffered at a time, the next request, including the one for a follow-up page, is sent\nonce all of them are read, so this also limits the memory used by buffered records.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
 		SourceConfigMethod: {
 			Default:     "GET",
 			Description: "Http method to use in the request",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
-				config.ValidationInclusion{List: []string{"GET", "HEAD", "OPTIONS"}},
+				config.ValidationInclusion{List: []string{"GET", "HEAD", "OPTIONS", "POST"}},
+			},
+		},
+		SourceConfigMetricsEnabled: {
+			Default:     "false",
+			Description: "Whether metrics about the sent requests (counts by status code and method, and a\nhistogram of durations) are recorded. The metrics are published using expvar under\nthe name `conduit_connector_http`.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigMode: {
+			Default:     "poll",
+			Description: "How data is fetched from the url. With `poll`, a request is sent every pollingPeriod.\nWith `longpoll`, the server is expected to hold the request open until data is\navailable, and a new request is sent right after each response, pollingPeriod is\nignored. With `webhook`, the connector doesn't send requests, but starts an HTTP\nserver and emits the body of each POST request it receives as a record. A request\nis answered with `200 OK` once its record is read, before the record is acked, so\nrecords can be lost if the connector stops in between (at-most-once delivery).\nRequest bodies larger than 10 MiB are rejected with `413 Request Entity Too Large`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"poll", "longpoll", "webhook"}},
+			},
+		},
+		SourceConfigPaginationLimitParam: {
+			Default:     "limit",
+			Description: "Name of the query parameter containing the page size, used with the `offset` pagination mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigPaginationMode: {
+			Default:     "",
+			Description: "Pagination mode used to fetch follow-up pages right away, without waiting for the\nnext poll. With `link`, the URL of the next page is taken from the `Link` response\nheader with the relation type `next`, until a response doesn't contain it.\nWith `offset`, the offset and limit query parameters are added to the request and\nthe offset is increased by the number of records in each page, until a page\ncontains fewer than pagination.pageSize records. The offset is stored in the\nrecord positions, `offset` requires a response that contains multiple records.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"link", "offset"}},
+			},
+		},
+		SourceConfigPaginationOffsetParam: {
+			Default:     "offset",
+			Description: "Name of the query parameter containing the offset, used with the `offset` pagination mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigPaginationPageSize: {
+			Default:     "100",
+			Description: "Number of records requested per page, used with the `offset` pagination mode.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
 			},
 		},
 		SourceConfigParams: {
@@ -39,31 +379,331 @@ func (SourceConfig) Parameters() map[string]config.Parameter {
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		SourceConfigParamsSeparator: {
+			Default:     "",
+			Description: "Separator used to split param values into multiple query parameters with the same\nname, e.g. with `,` the param `params.tag` set to `a,b` results in `tag=a&tag=b`.\nWhen empty, param values aren't split.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigPollingJitter: {
+			Default:     "",
+			Description: "Fraction by which each polling period is randomized, e.g. with `0.1` the time between\ntwo polls is a random value within ±10% of the polling period. Spreads out the requests\nof connectors polling the same API. Needs to be between 0 and 1.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
 		SourceConfigPollingPeriod: {
 			Default:     "5m",
 			Description: "how often the connector will get data from the url",
 			Type:        config.ParameterTypeDuration,
 			Validations: []config.Validation{},
 		},
+		SourceConfigPollingPeriodAdaptive: {
+			Default:     "",
+			Description: "Whether the polling period adapts to the amount of data. The period starts at\npollingPeriod, it's doubled after each poll that returns no records, up to\npollingPeriod.max, and halved after each poll that returns records, down to\npollingPeriod.min.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigPollingPeriodMax: {
+			Default:     "1h",
+			Description: "Maximum polling period used with pollingPeriod.adaptive.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigPollingPeriodMin: {
+			Default:     "1s",
+			Description: "Minimum polling period used with pollingPeriod.adaptive.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRateLimitBurst: {
+			Default:     "1",
+			Description: "Maximum number of requests sent at once when the rate limit allows it, e.g. after\nthe connector was idle, used with rateLimit.perSecond.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		SourceConfigRateLimitPerHost: {
+			Default:     "false",
+			Description: "Whether the rate limit applies to each host separately instead of to all requests of\nthe connector. The limit of a host is shared by all connectors in the same process\nthat enable this option, so the aggregate rate of requests to the host stays within\nit, regardless of the urls. If the connectors configure different limits, the most\nrestrictive one applies.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRateLimitPerSecond: {
+			Default:     "0",
+			Description: "Maximum number of requests sent per second on average, including retries, the\nrequests for further pages and the requests sent in parallel. Requests wait until\nthey're allowed to be sent. Zero means no limit.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRedactHeaders: {
+			Default:     "Authorization,Proxy-Authorization,Cookie,Set-Cookie",
+			Description: "Names of headers whose values are replaced with `***` in logs, comma separated list.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRedactQueryParams: {
+			Default:     "api_key,token",
+			Description: "Names of query parameters whose values are replaced with `***` in logs and error\nmessages, comma separated list.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRequestBody: {
+			Default:     "",
+			Description: "Body to send in the request, e.g. a JSON search query. The body is sent with any\nmethod, including GET. A body returned by getRequestData takes precedence.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRequestTimeout: {
+			Default:     "30s",
+			Description: "Maximum time a single request can take, including reading the response body.\nZero means no timeout.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseCursorPath: {
+			Default:     "",
+			Description: "Path to a cursor in a JSON response, e.g. `meta.nextCursor`, used with\nresponse.recordsPath(s) or graphql.query. The cursor is passed to getRequestData as `previousResponse.cursor`.\nA number or boolean is passed as a string, a number with its exact value.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseHeaderPrefix: {
+			Default:     "",
+			Description: "Prefix added to the names of the response headers in the record metadata, e.g.\n`http.header.`, to keep them apart from other metadata fields.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseKeyPath: {
+			Default:     "",
+			Description: "Path to the key within each record, e.g. `id`. With response.recordsPath, the key is\ntaken from each element of the records array, otherwise from the decoded response\n(or from each line with the `ndjson` format, or each row with the `csv` format). When\nnot set or not found, the time of the response is used as the key.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseKeyPaths: {
+			Default:     "",
+			Description: "Paths to the keys of the records of the arrays in response.recordsPaths, as a comma\nseparated list of : separated pairs of an array name and a path, e.g.\n`users:id,orders:order.id`. Arrays that aren't listed use response.keyPath.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseMetadataHeaders: {
+			Default:     "",
+			Description: "Response headers that are added to the record metadata, comma separated list. When\nempty, all headers are added. Setting it is recommended, as headers like `Set-Cookie`\ncan contain sensitive data.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseOperation: {
+			Default:     "create",
+			Description: "Operation of the records parsed without script.parseResponse, used when\nresponse.operationPath isn't set or its value isn't a known operation.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"create", "update", "delete", "snapshot"}},
+			},
+		},
+		SourceConfigResponseOperationMapping: {
+			Default:     "",
+			Description: "Mapping of values found at response.operationPath to operations, comma separated\nlist of : separated pairs, e.g. `deleted:delete,modified:update`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseOperationPath: {
+			Default:     "",
+			Description: "Path to a field in each record whose value determines the record's operation, e.g.\n`status`. The value is mapped using response.operationMapping, otherwise it's\nparsed as an operation name (create, update, delete or snapshot).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseProtobufDescriptorSet: {
+			Default:     "",
+			Description: "Path to a binary protobuf descriptor set containing the response message, used with\nresponseFormat protobuf. It can be created using\n`protoc --include_imports --descriptor_set_out=feed.binpb feed.proto`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseProtobufMessage: {
+			Default:     "",
+			Description: "Full name of the protobuf message the response is decoded as, e.g. `feed.v1.Batch`,\nused with responseFormat protobuf.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseRecordsPath: {
+			Default:     "",
+			Description: "Path to the array of records in a JSON response, e.g. `data.items`. When set and\nno script.parseResponse is configured, each element of the array is emitted as a\nseparate record.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseRecordsPaths: {
+			Default:     "",
+			Description: "Named arrays of records in a JSON response, used instead of response.recordsPath to\nextract records from multiple arrays, as a comma separated list of : separated pairs,\ne.g. `users:users,orders:data.orders`. The records of each array are emitted in the\nlisted order, and the name of the array is added to their metadata as\n`http.response.recordType`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseUseNumber: {
+			Default:     "false",
+			Description: "Whether numbers in JSON responses are decoded with their exact value, used with\nresponse.recordsPath, graphql.query and the `ndjson` format. By default numbers are\ndecoded as floating point numbers, which rounds integers larger than 2^53, e.g. the ID\n`1234567890123456789` becomes `1234567890123456800`. When enabled, numbers are kept as\ntheir JSON text (a `json.Number`, which is a string) and are encoded as the same number\nagain. Responses parsed using script.parseResponse are always decoded by JavaScript and\nhave the same limitation, in that case such values need to be strings in the response.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigResponseFormat: {
+			Default:     "raw",
+			Description: "Format of the response body, used when no script.parseResponse is configured.\nWith `raw`, the body is stored in the record as raw data. With `xml`, the body\nis decoded into structured data, where attributes are prefixed with `@` and\nthe text of elements that have attributes or child elements is stored as `#text`.\nWith `ndjson`, each non-empty line of the body is decoded as a JSON object into\na separate record. With `csv`, each row is decoded into a separate record, see\ncsv.delimiter and csv.hasHeader. With `protobuf`, the body is decoded as the protobuf message\nresponse.protobuf.message into structured data, using the protobuf JSON mapping\nwith the field names of the .proto file.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"raw", "xml", "ndjson", "csv", "protobuf"}},
+			},
+		},
+		SourceConfigRetryBackoff: {
+			Default:     "1s",
+			Description: "Delay before retrying a failed request for the first time. The Retry-After response\nheader takes precedence when present, a delay longer than 5 minutes is shortened.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRetryBackoffFactor: {
+			Default:     "2",
+			Description: "Factor by which the delay is multiplied after each retry, needs to be at least 1.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		SourceConfigRetryMaxAttempts: {
+			Default:     "3",
+			Description: "Maximum number of attempts for a request that fails with a transient network error (a\ntimeout, or a refused, reset or closed connection) or with one of the retry.statusCodes,\nincluding the first attempt. Network errors are only retried for GET, HEAD, OPTIONS and\nTRACE requests, and for requests of the destination with an idempotencyKey, since other\nrequests might have been processed by the server already.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		SourceConfigRetryStatusCodes: {
+			Default:     "429,502,503,504",
+			Description: "Response status codes for which the request is retried, comma separated list. Other unexpected status codes are treated as fatal and are not retried.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 		SourceConfigScriptGetRequestData: {
 			Default:     "",
-			Description: "The path to a .js file containing the code to prepare the request data.\nThe signature of the function needs to be:\n`function getRequestData(cfg, previousResponse, position)` where:\n* `cfg` (a map) is the connector configuration\n* `previousResponse` (a map) contains data from the previous response (if any), returned by `parseResponse`\n* `position` (a byte array) contains the starting position of the connector.\nThe function needs to return a Request object.",
+			Description: "The path to a .js file containing the code to prepare the request data, or the code itself.\nThe signature of the function needs to be:\n`function getRequestData(cfg, previousResponse, position)` where:\n* `cfg` (a map) is the connector configuration\n* `previousResponse` (a map) contains data from the previous response (if any), returned by `parseResponse`\n* `position` (a byte array) contains the starting position of the connector.\nThe function needs to return a Request object. Its `URL`, `Method` and `Body` are used\nfor the request, and its `Headers` (a map) are added to the configured headers. An empty\n`Method` or `Body` falls back to the configured one.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
 		SourceConfigScriptParseResponse: {
 			Default:     "",
-			Description: "The path to a .js file containing the code to parse the response.\nThe signature of the function needs to be:\n`function parseResponse(bytes)` where\n`bytes` are the original response's raw bytes (i.e. unparsed).\nThe response should be a Response object.",
+			Description: "The path to a .js file containing the code to parse the response, or the code itself.\nThe signature of the function needs to be:\n`function parseResponse(bytes, response)` where\n* `bytes` are the original response's raw bytes (i.e. unparsed)\n* `response` (an object) contains the response's `statusCode`, `headers` (multiple values are comma separated)\nand the `request` that produced it, with its `url` and `body`.\nThe response should be a Response object. Without pagination.mode, setting its `HasMore`\nto true requests the next page right away instead of waiting for the next poll.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigScriptTimeout: {
+			Default:     "30s",
+			Description: "Maximum time a call of script.getRequestData or script.parseResponse can take. A script\nthat doesn't finish in time, e.g. because it's stuck in a loop, is aborted and the read\nfails. Zero means no timeout.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigStartPosition: {
+			Default:     "",
+			Description: "Position the source starts from when it's started without a position, e.g. a\ntimestamp or a cursor. It's passed to getRequestData as the position and available\nas `{{.position}}` in url templates. With response.cursorPath, it's also used as the\ninitial cursor (`previousResponse.cursor` and `{{.cursor}}`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigTlsCaCert: {
+			Default:     "",
+			Description: "CA certificate used to verify the server's certificate, either the path to a\nPEM encoded file or the PEM encoded certificate itself. When not set, the\nsystem's root CAs are used.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigTlsClientCert: {
+			Default:     "",
+			Description: "Path to a PEM encoded client certificate used for mutual TLS, requires tls.clientKey to be set too.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
+		SourceConfigTlsClientKey: {
+			Default:     "",
+			Description: "Path to the PEM encoded private key of the client certificate, requires tls.clientCert to be set too.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigTlsInsecureSkipVerify: {
+			Default:     "false",
+			Description: "Skip verifying the server's certificate chain and host name. This should only\nbe used for testing, as it makes the connection vulnerable to man-in-the-middle attacks.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigTransportIdleConnTimeout: {
+			Default:     "90s",
+			Description: "Time after which an idle (keep-alive) connection is closed. Zero means no limit.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		SourceConfigTransportMaxIdleConns: {
+			Default:     "100",
+			Description: "Maximum number of idle (keep-alive) connections across all hosts. Zero means no limit.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		SourceConfigTransportMaxIdleConnsPerHost: {
+			Default:     "2",
+			Description: "Maximum number of idle (keep-alive) connections kept per host. Raising it lets\nconcurrent requests reuse connections instead of opening new ones.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
 		SourceConfigUrl: {
 			Default:     "",
-			Description: "Http url to send requests to",
+			Description: "Http url to send requests to, required unless urls is set or the mode is `webhook`.\nThe url can be a Go template, evaluated before each request against the data of the\nprevious response (e.g. `{{.cursor}}` with response.cursorPath) and the last\n`{{.position}}`, with the sprig functions, `pathEscape` and `queryEscape` available.\nValues that aren't set yet can be replaced using `default`, e.g.\n`?since={{.cursor | default \"0\" | queryEscape}}`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigUrls: {
+			Default:     "",
+			Description: "Http urls of mirror endpoints to send requests to, used instead of url. Each poll is\nsent to the next url in the list, and if a poll fails, it's retried against the\nfollowing urls before returning an error. A Request returned by getRequestData\ntakes precedence.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigValidateConnection: {
+			Default:     "true",
+			Description: "Whether a request is sent to check the connection when the connector starts.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigValidateConnectionMethod: {
+			Default:     "HEAD",
+			Description: "Http method of the request sent to check the connection when the connector starts.\nThe request is sent without a body.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
-				config.ValidationRequired{},
+				config.ValidationInclusion{List: []string{"HEAD", "GET", "OPTIONS"}},
 			},
 		},
+		SourceConfigValidateConnectionPreflight: {
+			Default:     "false",
+			Description: "Whether an OPTIONS request is sent to each url when the connector starts, to check\nthat method is one of the methods listed in the `Allow` response header. The check\npasses if the server doesn't return an `Allow` header.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		SourceConfigValidateConnectionUrl: {
+			Default:     "",
+			Description: "Url of the request sent to check the connection when the connector starts, e.g. a\nhealth endpoint. When empty, the check is sent to every url data is fetched from.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigWebhookListenAddr: {
+			Default:     ":8080",
+			Description: "Address the HTTP server listens on in the `webhook` mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigWebhookPath: {
+			Default:     "/",
+			Description: "Path the HTTP server accepts requests on in the `webhook` mode.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigWebhookSecret: {
+			Default:     "",
+			Description: "Shared secret expected in the webhook.secretHeader header of incoming requests in\nthe `webhook` mode, requests with a different value are rejected with 401 Unauthorized.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		SourceConfigWebhookSecretHeader: {
+			Default:     "X-Webhook-Secret",
+			Description: "Name of the header containing the shared secret, used with webhook.secret.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
 	}
 }
@@ -0,0 +1,106 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// validatePolling validates the options of the polling period.
+func (s *SourceConfig) validatePolling() error {
+	if s.PollingJitter < 0 || s.PollingJitter >= 1 {
+		return errors.New("pollingJitter needs to be greater than or equal to 0 and less than 1")
+	}
+	if !s.PollingPeriodAdaptive {
+		return nil
+	}
+	if s.PollingPeriodMin <= 0 {
+		return errors.New("pollingPeriod.min needs to be greater than 0")
+	}
+	if s.PollingPeriodMax < s.PollingPeriodMin {
+		return errors.New("pollingPeriod.max needs to be greater than or equal to pollingPeriod.min")
+	}
+	return nil
+}
+
+// waitForPoll blocks until the next poll is due, which is one jittered polling
+// period after the start of the last poll. The first poll is due right away.
+func (s *Source) waitForPoll(ctx context.Context) error {
+	if !s.lastPoll.IsZero() {
+		timer := time.NewTimer(time.Until(s.lastPoll.Add(s.jitteredPollingPeriod())))
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	s.lastPoll = time.Now()
+	return nil
+}
+
+// jitteredPollingPeriod returns the current polling period, randomized by
+// ±pollingJitter.
+func (s *Source) jitteredPollingPeriod() time.Duration {
+	if s.config.PollingJitter == 0 {
+		return s.pollingPeriod
+	}
+	//nolint:gosec // the jitter doesn't need a cryptographically secure random number
+	factor := 1 + s.config.PollingJitter*(2*rand.Float64()-1)
+	return time.Duration(float64(s.pollingPeriod) * factor)
+}
+
+// adaptPollingPeriod adjusts the polling period to the number of records
+// returned by the last poll, if the adaptive polling period is enabled. The
+// period is doubled after a poll without records and halved after a poll
+// with records, within pollingPeriod.min and pollingPeriod.max.
+func (s *Source) adaptPollingPeriod(ctx context.Context, records int) {
+	if !s.config.PollingPeriodAdaptive {
+		return
+	}
+
+	period := s.pollingPeriod
+	if records == 0 {
+		period = min(period*2, s.config.PollingPeriodMax)
+	} else {
+		period = max(period/2, s.config.PollingPeriodMin)
+	}
+	if period == s.pollingPeriod {
+		return
+	}
+
+	sdk.Logger(ctx).Debug().
+		Dur("previous", s.pollingPeriod).
+		Dur("current", period).
+		Msg("adapted polling period")
+	s.pollingPeriod = period
+}
+
+// heartbeat logs that the last request returned no records, if emitHeartbeat
+// is enabled.
+func (s *Source) heartbeat(ctx context.Context) {
+	if !s.config.EmitHeartbeat {
+		return
+	}
+	sdk.Logger(ctx).Info().
+		Time("lastPoll", s.lastPoll).
+		Dur("pollingPeriod", s.pollingPeriod).
+		Msg("no new records, source is idle")
+}
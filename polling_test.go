@@ -0,0 +1,149 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/matryer/is"
+	"github.com/rs/zerolog"
+)
+
+func TestSource_AdaptPollingPeriod(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	src := &Source{
+		config: SourceConfig{
+			PollingPeriod:         time.Minute,
+			PollingPeriodAdaptive: true,
+			PollingPeriodMin:      20 * time.Second,
+			PollingPeriodMax:      3 * time.Minute,
+		},
+		pollingPeriod: time.Minute,
+	}
+
+	// backs off while polls return no records
+	src.adaptPollingPeriod(ctx, 0)
+	is.Equal(src.pollingPeriod, 2*time.Minute)
+	src.adaptPollingPeriod(ctx, 0)
+	is.Equal(src.pollingPeriod, 3*time.Minute)
+
+	// shrinks while polls return records
+	src.adaptPollingPeriod(ctx, 5)
+	is.Equal(src.pollingPeriod, 90*time.Second)
+	src.adaptPollingPeriod(ctx, 5)
+	is.Equal(src.pollingPeriod, 45*time.Second)
+	src.adaptPollingPeriod(ctx, 5)
+	src.adaptPollingPeriod(ctx, 5)
+	is.Equal(src.pollingPeriod, 20*time.Second)
+}
+
+func TestSource_FixedPollingPeriod(t *testing.T) {
+	is := is.New(t)
+
+	src := &Source{
+		config:        SourceConfig{PollingPeriod: time.Minute},
+		pollingPeriod: time.Minute,
+	}
+	src.adaptPollingPeriod(context.Background(), 0)
+	is.Equal(src.pollingPeriod, time.Minute)
+}
+
+func TestSource_PollingJitter(t *testing.T) {
+	is := is.New(t)
+
+	src := &Source{
+		config:        SourceConfig{PollingJitter: 0.1},
+		pollingPeriod: time.Minute,
+	}
+	for range 100 {
+		got := src.jitteredPollingPeriod()
+		is.True(got >= 54*time.Second)
+		is.True(got <= 66*time.Second)
+	}
+}
+
+func TestSource_WaitForPoll(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	src := &Source{pollingPeriod: 50 * time.Millisecond}
+
+	// the first poll is due right away
+	start := time.Now()
+	is.NoErr(src.waitForPoll(ctx))
+	is.NoErr(src.waitForPoll(ctx))
+	is.True(time.Since(start) >= 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	is.Equal(src.waitForPoll(ctx), context.Canceled)
+}
+
+func TestSourceConfig_ValidatePolling(t *testing.T) {
+	is := is.New(t)
+
+	cfg := SourceConfig{
+		PollingPeriodAdaptive: true,
+		PollingPeriodMin:      time.Minute,
+		PollingPeriodMax:      time.Second,
+	}
+	is.True(cfg.validatePolling() != nil)
+
+	cfg.PollingPeriodMax = time.Hour
+	is.NoErr(cfg.validatePolling())
+
+	cfg.PollingJitter = 1
+	is.True(cfg.validatePolling() != nil)
+}
+
+func TestSource_EmitHeartbeat(t *testing.T) {
+	is := is.New(t)
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).WithContext(context.Background())
+
+	// an empty ndjson response contains no records
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	for _, enabled := range []bool{false, true} {
+		logs.Reset()
+		src := NewSource()
+		err := src.Configure(ctx, map[string]string{
+			"url":            server.URL,
+			"responseFormat": "ndjson",
+			"emitHeartbeat":  strconv.FormatBool(enabled),
+		})
+		is.NoErr(err)
+		err = src.Open(ctx, opencdc.Position{})
+		is.NoErr(err)
+
+		_, err = src.Read(ctx)
+		is.True(errors.Is(err, sdk.ErrBackoffRetry))
+		is.Equal(strings.Contains(logs.String(), `"message":"no new records, source is idle"`), enabled)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// sourcePosition wraps the position of a record together with the state
+// needed to continue reading, so that the source can resume where it left off
+// after a restart.
+type sourcePosition struct {
+	// Position is the position of the record returned by the response parser.
+	Position opencdc.Position `json:"position,omitempty"`
+	// Offset is the offset of the next record when using offset pagination.
+	Offset int `json:"offset,omitempty"`
+	// ResponseData is the custom data returned by the response parser, which
+	// is passed to getRequestData when building the next request.
+	ResponseData map[string]any `json:"responseData,omitempty"`
+	// ETag is the ETag of the last response, sent with the next conditional
+	// request.
+	ETag string `json:"etag,omitempty"`
+	// LastModified is the time sent in the If-Modified-Since header of the
+	// next conditional request.
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// wrapsPosition returns true if the positions of the records produced by the
+// source contain the source state.
+func (s *Source) wrapsPosition() bool {
+	return s.config.PaginationMode == paginationModeOffset || s.responseParser != nil || s.config.ConditionalRequests
+}
+
+// state returns the current state of the source, without a record position.
+func (s *Source) state() sourcePosition {
+	return sourcePosition{
+		Offset:       s.offset,
+		ResponseData: s.lastResponseData,
+		ETag:         s.lastETag,
+		LastModified: s.lastModified,
+	}
+}
+
+// wrapPositions adds the source state to the positions of the records parsed
+// from a single response. prev is the state before the response was received.
+func (s *Source) wrapPositions(records []opencdc.Record, prev sourcePosition) error {
+	if !s.wrapsPosition() {
+		return nil
+	}
+
+	for i := range records {
+		sp := sourcePosition{
+			Position: records[i].Position,
+			// until the last record of a response is read, the same response
+			// needs to be fetched again after a restart
+			ResponseData: prev.ResponseData,
+			ETag:         prev.ETag,
+			LastModified: prev.LastModified,
+		}
+		if i == len(records)-1 {
+			sp.ResponseData = s.lastResponseData
+			sp.ETag = s.lastETag
+			sp.LastModified = s.lastModified
+		}
+		if s.config.PaginationMode == paginationModeOffset {
+			sp.Offset = prev.Offset + i + 1
+		}
+
+		pos, err := json.Marshal(sp)
+		if err != nil {
+			return fmt.Errorf("failed marshaling position: %w", err)
+		}
+		records[i].Position = pos
+	}
+	return nil
+}
+
+// unwrapPosition returns the position of the record as returned by the
+// response parser.
+func (s *Source) unwrapPosition(pos opencdc.Position) opencdc.Position {
+	if !s.wrapsPosition() {
+		return pos
+	}
+	var sp sourcePosition
+	if err := json.Unmarshal(pos, &sp); err != nil {
+		return pos
+	}
+	return sp.Position
+}
+
+// restorePosition restores the state of the source from the position it is
+// started with. Without a position, the source starts from startPosition.
+func (s *Source) restorePosition(ctx context.Context, pos opencdc.Position) {
+	if len(pos) == 0 && s.config.StartPosition != "" {
+		sdk.Logger(ctx).Info().Str("startPosition", s.config.StartPosition).Msg("no position, starting from startPosition")
+		s.lastPosition = opencdc.Position(s.config.StartPosition)
+		if s.config.ResponseCursorPath != "" {
+			s.lastResponseData = map[string]any{cursorKey: s.config.StartPosition}
+		}
+		return
+	}
+
+	s.lastPosition = pos
+	if !s.wrapsPosition() || len(pos) == 0 {
+		return
+	}
+
+	var sp sourcePosition
+	if err := json.Unmarshal(pos, &sp); err != nil {
+		// e.g. a position from an older version of the connector
+		sdk.Logger(ctx).Warn().Err(err).Msg("position doesn't contain the source state, starting from scratch")
+		return
+	}
+	s.lastPosition = sp.Position
+	s.offset = sp.Offset
+	s.lastResponseData = sp.ResponseData
+	s.lastETag = sp.ETag
+	s.lastModified = sp.LastModified
+}
@@ -0,0 +1,79 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const responseFormatProtobuf = "protobuf"
+
+// loadProtoMessage reads a binary FileDescriptorSet from path and returns the
+// descriptor of the message with the given full name, e.g. `feed.v1.Batch`.
+func loadProtoMessage(path, name string) (protoreflect.MessageDescriptor, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading protobuf descriptor set: %w", err)
+	}
+	var set descriptorpb.FileDescriptorSet
+	err = proto.Unmarshal(b, &set)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding protobuf descriptor set: %w", err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protobuf descriptor set: %w", err)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed finding protobuf message %q: %w", name, err)
+	}
+	msg, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("protobuf descriptor %q is not a message", name)
+	}
+	return msg, nil
+}
+
+// parseProtobuf decodes a protobuf message into structured data, using the
+// JSON mapping of protobuf with the field names used in the .proto file. 64-bit
+// integers are strings in that mapping, so they keep their exact value.
+func parseProtobuf(body []byte, desc protoreflect.MessageDescriptor) (opencdc.StructuredData, error) {
+	msg := dynamicpb.NewMessage(desc)
+	err := proto.Unmarshal(body, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding protobuf message %v: %w", desc.FullName(), err)
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting protobuf message %v: %w", desc.FullName(), err)
+	}
+	var sd opencdc.StructuredData
+	err = json.Unmarshal(b, &sd)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting protobuf message %v: %w", desc.FullName(), err)
+	}
+	return sd, nil
+}
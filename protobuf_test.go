@@ -0,0 +1,133 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// writeTestDescriptorSet writes a descriptor set with the message
+// test.v1.Event to a file and returns its path.
+func writeTestDescriptorSet(t *testing.T) string {
+	t.Helper()
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+	}
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("test/v1/event.proto"),
+		Package: proto.String("test.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Event"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("event_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				field("count", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL),
+				field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, descriptorpb.FieldDescriptorProto_LABEL_REPEATED),
+			},
+		}},
+	}}}
+	b, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "event.binpb")
+	err = os.WriteFile(path, b, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSource_ProtobufResponse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	path := writeTestDescriptorSet(t)
+	desc, err := loadProtoMessage(path, "test.v1.Event")
+	is.NoErr(err)
+
+	msg := dynamicpb.NewMessage(desc)
+	msg.Set(desc.Fields().ByName("event_id"), protoreflect.ValueOfString("e-1"))
+	msg.Set(desc.Fields().ByName("count"), protoreflect.ValueOfInt32(3))
+	tags := msg.Mutable(desc.Fields().ByName("tags")).List()
+	tags.Append(protoreflect.ValueOfString("a"))
+	tags.Append(protoreflect.ValueOfString("b"))
+	body, err := proto.Marshal(msg)
+	is.NoErr(err)
+
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err = src.Configure(ctx, map[string]string{
+		"url":                             server.URL,
+		"responseFormat":                  "protobuf",
+		"response.protobuf.descriptorSet": path,
+		"response.protobuf.message":       "test.v1.Event",
+		"response.keyPath":                "event_id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(cmp.Diff(opencdc.StructuredData{
+		"event_id": "e-1",
+		"count":    float64(3),
+		"tags":     []any{"a", "b"},
+	}, rec.Payload.After), "")
+	is.Equal(rec.Key, opencdc.RawData("e-1"))
+	is.Equal(accept, "application/x-protobuf")
+}
+
+func TestLoadProtoMessage_Invalid(t *testing.T) {
+	is := is.New(t)
+
+	path := writeTestDescriptorSet(t)
+	_, err := loadProtoMessage(path, "test.v1.Missing")
+	is.True(err != nil)
+	_, err = loadProtoMessage(filepath.Join(t.TempDir(), "missing.binpb"), "test.v1.Event")
+	is.True(err != nil)
+
+	err = NewSource().Configure(context.Background(), map[string]string{
+		"url":            "http://localhost",
+		"responseFormat": "protobuf",
+	})
+	is.True(err != nil)
+}
@@ -0,0 +1,165 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// sharedLimiter is the limiter shared by the requests to a host, together
+// with the round trippers that use it.
+type sharedLimiter struct {
+	limiter *rate.Limiter
+	users   map[*rateLimitRoundTripper]struct{}
+}
+
+// hostLimiters contains the limiters shared by all connectors in the process
+// that have rateLimit.perHost enabled, by host. A limiter is removed once no
+// connector uses it anymore.
+var hostLimiters = struct {
+	sync.Mutex
+	m map[string]*sharedLimiter
+}{m: make(map[string]*sharedLimiter)}
+
+// acquireHostLimiter returns the limiter shared by the requests to host and
+// registers t as one of its users. If the users configure different limits,
+// the most restrictive one applies to all of them.
+func acquireHostLimiter(host string, t *rateLimitRoundTripper) *rate.Limiter {
+	hostLimiters.Lock()
+	defer hostLimiters.Unlock()
+	shared, ok := hostLimiters.m[host]
+	if !ok {
+		shared = &sharedLimiter{
+			limiter: rate.NewLimiter(t.limiter.Limit(), t.limiter.Burst()),
+			users:   make(map[*rateLimitRoundTripper]struct{}),
+		}
+		hostLimiters.m[host] = shared
+	}
+	shared.users[t] = struct{}{}
+	shared.updateLimit()
+	return shared.limiter
+}
+
+// releaseHostLimiter unregisters t as a user of the limiter of host. The
+// limiter is removed once it has no users left, otherwise the limit of the
+// remaining users applies.
+func releaseHostLimiter(host string, t *rateLimitRoundTripper) {
+	hostLimiters.Lock()
+	defer hostLimiters.Unlock()
+	shared, ok := hostLimiters.m[host]
+	if !ok {
+		return
+	}
+	delete(shared.users, t)
+	if len(shared.users) == 0 {
+		delete(hostLimiters.m, host)
+		return
+	}
+	shared.updateLimit()
+}
+
+// updateLimit sets the limit and burst of the shared limiter to the lowest
+// ones configured by its users.
+func (s *sharedLimiter) updateLimit() {
+	limit, burst := rate.Inf, math.MaxInt
+	for t := range s.users {
+		limit = min(limit, t.limiter.Limit())
+		burst = min(burst, t.limiter.Burst())
+	}
+	if limit != s.limiter.Limit() {
+		s.limiter.SetLimit(limit)
+	}
+	if burst != s.limiter.Burst() {
+		s.limiter.SetBurst(burst)
+	}
+}
+
+// rateLimitRoundTripper waits for the limiter before sending each request
+// using the base round tripper, including retries. The limiter is safe for
+// concurrent use, so requests sent in parallel share the same limit. Waiting
+// stops when the context of the request is done.
+//
+// With perHost, the limiter only defines the limit and each request waits for
+// the limiter shared by all requests to its host instead, see
+// acquireHostLimiter. The shared limiters are released by release.
+type rateLimitRoundTripper struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+	perHost bool
+
+	mu sync.Mutex
+	// hosts contains the shared limiters used by the round tripper, by host
+	hosts map[string]*rate.Limiter
+}
+
+func (t *rateLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limiter
+	if t.perHost {
+		limiter = t.hostLimiter(req.URL.Host)
+	}
+	err := limiter.Wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// hostLimiter returns the shared limiter of host, which is acquired on first
+// use.
+func (t *rateLimitRoundTripper) hostLimiter(host string) *rate.Limiter {
+	host = strings.ToLower(host)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limiter, ok := t.hosts[host]
+	if !ok {
+		limiter = acquireHostLimiter(host, t)
+		if t.hosts == nil {
+			t.hosts = make(map[string]*rate.Limiter)
+		}
+		t.hosts[host] = limiter
+	}
+	return limiter
+}
+
+// release releases the shared limiters used by the round tripper, it's called
+// when the connector is torn down.
+func (t *rateLimitRoundTripper) release() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for host := range t.hosts {
+		releaseHostLimiter(host, t)
+	}
+	t.hosts = nil
+}
+
+// releaseRateLimit releases the shared limiters of rt, if it's a
+// rateLimitRoundTripper.
+func releaseRateLimit(rt http.RoundTripper) {
+	if t, ok := rt.(*rateLimitRoundTripper); ok {
+		t.release()
+	}
+}
+
+// CloseIdleConnections closes the idle connections of the base round tripper,
+// it's called by http.Client.CloseIdleConnections.
+func (t *rateLimitRoundTripper) CloseIdleConnections() {
+	closeIdleConnections(t.base)
+}
@@ -0,0 +1,272 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+	"golang.org/x/time/rate"
+)
+
+func TestDestination_RateLimit(t *testing.T) {
+	for _, concurrency := range []string{"1", "4"} {
+		t.Run("concurrency "+concurrency, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+			}))
+			t.Cleanup(server.Close)
+
+			dest := NewDestination()
+			err := dest.Configure(ctx, map[string]string{
+				"url":                 server.URL,
+				"concurrency":         concurrency,
+				"rateLimit.perSecond": "20",
+				"validateConnection":  "false",
+			})
+			is.NoErr(err)
+			err = dest.Open(ctx)
+			is.NoErr(err)
+
+			records := make([]opencdc.Record, 5)
+			for i := range records {
+				records[i].Payload.After = opencdc.RawData("x")
+			}
+			start := time.Now()
+			n, err := dest.Write(ctx, records)
+			is.NoErr(err)
+			is.Equal(n, 5)
+			is.Equal(requests.Load(), int32(5))
+			// the first request is sent right away, the others every 50ms
+			is.True(time.Since(start) >= 190*time.Millisecond)
+		})
+	}
+}
+
+func TestDestination_RateLimitContext(t *testing.T) {
+	is := is.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(context.Background(), map[string]string{
+		"url":                 server.URL,
+		"rateLimit.perSecond": "0.1",
+		"validateConnection":  "false",
+	})
+	is.NoErr(err)
+	err = dest.Open(context.Background())
+	is.NoErr(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	records := []opencdc.Record{
+		{Payload: opencdc.Change{After: opencdc.RawData("a")}},
+		{Payload: opencdc.Change{After: opencdc.RawData("b")}},
+	}
+	start := time.Now()
+	n, err := dest.Write(ctx, records)
+	is.True(err != nil)
+	is.Equal(n, 1)
+	// the limiter doesn't wait for the next token if the context ends before
+	is.True(time.Since(start) < time.Second)
+}
+
+func TestDestination_RateLimitPerHost(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	t.Cleanup(server.Close)
+
+	// two destinations with the same limit share the limiter of the host
+	dests := make([]*Destination, 2)
+	for i := range dests {
+		dests[i] = &Destination{}
+		err := dests[i].Configure(ctx, map[string]string{
+			"url":                 server.URL + "/endpoint" + string(rune('a'+i)),
+			"rateLimit.perSecond": "20",
+			"rateLimit.perHost":   "true",
+			"validateConnection":  "false",
+		})
+		is.NoErr(err)
+		err = dests[i].Open(ctx)
+		is.NoErr(err)
+	}
+
+	records := make([]opencdc.Record, 3)
+	for i := range records {
+		records[i].Payload.After = opencdc.RawData("x")
+	}
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, d := range dests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := d.Write(ctx, records)
+			is.NoErr(err)
+		}()
+	}
+	wg.Wait()
+	is.Equal(requests.Load(), int32(6))
+	// 6 requests at 20 per second, instead of 3 for each destination
+	is.True(time.Since(start) >= 240*time.Millisecond)
+
+	// the limiter of the host is removed when the last destination is torn down
+	host := strings.TrimPrefix(server.URL, "http://")
+	for _, d := range dests {
+		hostLimiters.Lock()
+		_, ok := hostLimiters.m[host]
+		hostLimiters.Unlock()
+		is.True(ok)
+		is.NoErr(d.Teardown(ctx))
+	}
+	hostLimiters.Lock()
+	_, ok := hostLimiters.m[host]
+	hostLimiters.Unlock()
+	is.True(!ok)
+}
+
+func TestHostLimiter(t *testing.T) {
+	is := is.New(t)
+
+	slow := &rateLimitRoundTripper{limiter: rate.NewLimiter(5, 2), perHost: true}
+	fast := &rateLimitRoundTripper{limiter: rate.NewLimiter(10, 4), perHost: true}
+
+	limiter := fast.hostLimiter("Example.com:8080")
+	is.Equal(limiter.Limit(), rate.Limit(10))
+	is.True(fast.hostLimiter("example.com:8080") == limiter)
+	is.True(fast.hostLimiter("example.com:8081") != limiter)
+
+	// the most restrictive limit applies to all connectors
+	is.True(slow.hostLimiter("example.com:8080") == limiter)
+	is.Equal(limiter.Limit(), rate.Limit(5))
+	is.Equal(limiter.Burst(), 2)
+
+	slow.release()
+	is.Equal(limiter.Limit(), rate.Limit(10))
+	is.Equal(limiter.Burst(), 4)
+
+	// the limiters are removed once they're not used anymore
+	fast.release()
+	hostLimiters.Lock()
+	defer hostLimiters.Unlock()
+	_, ok := hostLimiters.m["example.com:8080"]
+	is.True(!ok)
+	_, ok = hostLimiters.m["example.com:8081"]
+	is.True(!ok)
+}
+
+func TestSource_RateLimit(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		w.Header().Set("Link", fmt.Sprintf(`</items?page=%d>; rel="next"`, page+1))
+		fmt.Fprintf(w, "page %d", page)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                 server.URL + "/items",
+		"pagination.mode":     "link",
+		"pollingPeriod":       "1h",
+		"rateLimit.perSecond": "20",
+		"rateLimit.burst":     "2",
+		"validateConnection":  "false",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	start := time.Now()
+	for i := 1; i <= 5; i++ {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(rec.Payload.After.Bytes()), fmt.Sprintf("page %d", i))
+	}
+	// the first 2 pages are fetched right away, the others every 50ms,
+	// regardless of the polling period
+	elapsed := time.Since(start)
+	is.True(elapsed >= 140*time.Millisecond)
+	is.True(elapsed < time.Second)
+}
+
+func TestSource_RateLimitPerHostTeardown(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                 server.URL,
+		"pollingPeriod":       "1h",
+		"rateLimit.perSecond": "20",
+		"rateLimit.perHost":   "true",
+		"validateConnection":  "false",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+	_, err = src.Read(ctx)
+	is.NoErr(err)
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	hostLimiters.Lock()
+	_, ok := hostLimiters.m[host]
+	hostLimiters.Unlock()
+	is.True(ok)
+
+	is.NoErr(src.Teardown(ctx))
+	hostLimiters.Lock()
+	_, ok = hostLimiters.m[host]
+	hostLimiters.Unlock()
+	is.True(!ok)
+}
+
+func TestConfig_RateLimitNegative(t *testing.T) {
+	is := is.New(t)
+	config := Config{
+		RetryBackoffFactor: 2,
+		RateLimitPerSecond: -1,
+	}
+	is.True(config.Validate() != nil)
+}
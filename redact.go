@@ -0,0 +1,102 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// redacted replaces sensitive values in logs and error messages.
+const redacted = "***"
+
+// redactURL returns the URL with the values of the sensitive query parameters
+// (redact.queryParams and auth.apiKey.queryParam) replaced. The rest of the URL is left as is.
+func (s *Config) redactURL(rawURL string) string {
+	before, query, ok := strings.Cut(rawURL, "?")
+	if !ok {
+		return rawURL
+	}
+	query, fragment, hasFragment := strings.Cut(query, "#")
+
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(key); err == nil && s.isSensitiveQueryParam(name) {
+			pairs[i] = key + "=" + redacted
+		}
+	}
+
+	redactedURL := before + "?" + strings.Join(pairs, "&")
+	if hasFragment {
+		redactedURL += "#" + fragment
+	}
+	return redactedURL
+}
+
+func (s *Config) isSensitiveQueryParam(name string) bool {
+	if s.APIKeyQueryParam != "" && strings.EqualFold(s.APIKeyQueryParam, name) {
+		return true
+	}
+	return slices.ContainsFunc(s.RedactQueryParams, func(param string) bool {
+		return strings.EqualFold(param, name)
+	})
+}
+
+// redactHeaders returns the headers as a map with the values of the sensitive
+// headers (redact.headers and auth.apiKey.header) replaced.
+func (s *Config) redactHeaders(header http.Header) map[string]string {
+	out := make(map[string]string, len(header))
+	for key, val := range header {
+		out[key] = strings.Join(val, ",")
+	}
+	sensitive := s.RedactHeaders
+	if s.APIKeyHeader != "" {
+		sensitive = append(slices.Clip(sensitive), s.APIKeyHeader)
+	}
+	for _, key := range sensitive {
+		key = http.CanonicalHeaderKey(key)
+		if _, ok := out[key]; ok {
+			out[key] = redacted
+		}
+	}
+	return out
+}
+
+// redactError redacts the URL contained in an error returned by the HTTP
+// client or by the url package, so that it can be included in error messages.
+func (s *Config) redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = s.redactURL(urlErr.URL)
+	}
+	return err
+}
+
+// redactSecrets replaces the configured credentials (basic auth password,
+// OAuth2 client secret and refresh token, and API key) in text that is
+// returned by a server and logged or included in an error, e.g. when a
+// response body is echoing the request.
+func (s *Config) redactSecrets(text string) string {
+	for _, secret := range []string{s.BasicAuthPassword, s.OAuth2ClientSecret, s.OAuth2RefreshToken, s.APIKeyValue} {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+	return text
+}
@@ -0,0 +1,102 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestConfig_RedactURL(t *testing.T) {
+	cfg := Config{RedactQueryParams: []string{"api_key", "token"}}
+
+	testCases := []struct {
+		url  string
+		want string
+	}{{
+		url:  "https://example.com/path",
+		want: "https://example.com/path",
+	}, {
+		url:  "https://example.com/path?id=1&API_KEY=secret&token=abc&token=def",
+		want: "https://example.com/path?id=1&API_KEY=***&token=***&token=***",
+	}, {
+		url:  "https://example.com/?token#fragment",
+		want: "https://example.com/?token=***#fragment",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(cfg.redactURL(tc.url), tc.want)
+		})
+	}
+}
+
+func TestConfig_RedactHeaders(t *testing.T) {
+	is := is.New(t)
+
+	cfg := Config{RedactHeaders: []string{"authorization", "X-Api-Key"}}
+	got := cfg.redactHeaders(http.Header{
+		"Authorization": {"Bearer secret"},
+		"X-Api-Key":     {"secret"},
+		"Accept":        {"text/plain", "application/json"},
+	})
+	is.Equal(got, map[string]string{
+		"Authorization": "***",
+		"X-Api-Key":     "***",
+		"Accept":        "text/plain,application/json",
+	})
+}
+
+func TestConfig_RedactAPIKey(t *testing.T) {
+	is := is.New(t)
+
+	cfg := Config{APIKeyQueryParam: "key"}
+	is.Equal(cfg.redactURL("https://example.com/?key=secret&id=1"), "https://example.com/?key=***&id=1")
+
+	cfg = Config{RedactHeaders: []string{"Authorization"}, APIKeyHeader: "x-api-key"}
+	got := cfg.redactHeaders(http.Header{"X-Api-Key": {"secret"}})
+	is.Equal(got, map[string]string{"X-Api-Key": "***"})
+
+	cfg = Config{APIKeyValue: "secret"}
+	is.Equal(cfg.redactSecrets(`{"key":"secret"}`), `{"key":"***"}`)
+}
+
+func TestSource_RedactErrors(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close() // requests fail with a connection error
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                url + "?api_key=secret-key&custom=secret-custom",
+		"redact.queryParams": "api_key,custom",
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "api_key=***&custom=***"))
+	is.True(!strings.Contains(err.Error(), "secret"))
+}
@@ -0,0 +1,190 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	responseFormatRaw    = "raw"
+	responseFormatXML    = "xml"
+	responseFormatNDJSON = "ndjson"
+	responseFormatCSV    = "csv"
+)
+
+// acceptHeader returns the value of the Accept header sent with requests.
+// Unless configured, it's derived from the expected response, it's empty if
+// the response can be anything (the raw format without JSON parsing).
+func (s *SourceConfig) acceptHeader() string {
+	switch {
+	case s.AcceptHeader != "":
+		return s.AcceptHeader
+	case s.ResponseFormat == responseFormatXML:
+		return "application/xml"
+	case s.ResponseFormat == responseFormatNDJSON:
+		return "application/x-ndjson, application/json"
+	case s.ResponseFormat == responseFormatProtobuf:
+		return "application/x-protobuf"
+	case s.ResponseFormat == responseFormatCSV:
+		return "text/csv"
+	case s.hasRecordsPath() || s.GraphQLQuery != "":
+		return "application/json"
+	default:
+		return ""
+	}
+}
+
+// parseNDJSON decodes newline delimited JSON, where each non-empty line
+// contains a JSON object, into one structured data per line. With useNumber,
+// numbers are decoded as json.Number.
+func parseNDJSON(body []byte, useNumber bool) ([]opencdc.Data, error) {
+	var out []opencdc.Data
+	for i, line := range bytes.Split(body, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var sd opencdc.StructuredData
+		if err := decodeJSON(line, &sd, useNumber); err != nil {
+			return nil, fmt.Errorf("failed decoding JSON on line %d: %w", i+1, err)
+		}
+		out = append(out, sd)
+	}
+	return out, nil
+}
+
+// csvDelimiter returns the configured delimiter of CSV responses.
+func (s *SourceConfig) csvDelimiter() (rune, error) {
+	runes := []rune(s.CSVDelimiter)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' || runes[0] == utf8.RuneError {
+		return 0, fmt.Errorf("invalid csv.delimiter %q, needs to be a single character other than a quote or newline", s.CSVDelimiter)
+	}
+	return runes[0], nil
+}
+
+// parseCSV decodes CSV into one structured data per row. With a header, the
+// values of the first row are used as the keys of the following rows,
+// otherwise the keys are the indexes of the columns ("0", "1", ...). Quoted
+// fields can contain the delimiter, quotes and newlines.
+func parseCSV(body []byte, delimiter rune, hasHeader bool) ([]opencdc.Data, error) {
+	r := csv.NewReader(bytes.NewReader(body))
+	r.Comma = delimiter
+
+	var header []string
+	var out []opencdc.Data
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding CSV: %w", err)
+		}
+		if hasHeader && header == nil {
+			header = row
+			continue
+		}
+
+		sd := make(opencdc.StructuredData, len(row))
+		for i, val := range row {
+			if hasHeader {
+				sd[header[i]] = val
+			} else {
+				sd[strconv.Itoa(i)] = val
+			}
+		}
+		out = append(out, sd)
+	}
+}
+
+// parseXML decodes an XML document into structured data. The root element is
+// the only key of the returned map. An element without attributes and child
+// elements is decoded into its text, otherwise it's decoded into a map, where
+// attributes are prefixed with "@", child elements are stored under their
+// name (repeated elements as a slice) and text is stored under "#text".
+func parseXML(body []byte) (opencdc.StructuredData, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("XML document has no root element")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding XML: %w", err)
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			val, err := parseXMLElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			return opencdc.StructuredData{start.Name.Local: val}, nil
+		}
+	}
+}
+
+// parseXMLElement decodes the element which starts with the start token.
+func parseXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	elem := make(map[string]any)
+	for _, attr := range start.Attr {
+		elem["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed decoding XML element %q: %w", start.Name.Local, err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := parseXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			switch existing := elem[t.Name.Local].(type) {
+			case nil:
+				elem[t.Name.Local] = child
+			case []any:
+				elem[t.Name.Local] = append(existing, child)
+			default:
+				elem[t.Name.Local] = []any{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			trimmed := strings.TrimSpace(text.String())
+			if len(elem) == 0 {
+				return trimmed, nil
+			}
+			if trimmed != "" {
+				elem["#text"] = trimmed
+			}
+			return elem, nil
+		}
+	}
+}
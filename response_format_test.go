@@ -0,0 +1,295 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestParseXML(t *testing.T) {
+	is := is.New(t)
+
+	got, err := parseXML([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<envelope version="1.1">
+	<header>ignored</header>
+	<item id="1">first</item>
+	<item id="2"><name>second</name><tag>a</tag><tag>b</tag></item>
+	<empty/>
+</envelope>`))
+	is.NoErr(err)
+
+	want := opencdc.StructuredData{
+		"envelope": map[string]any{
+			"@version": "1.1",
+			"header":   "ignored",
+			"item": []any{
+				map[string]any{"@id": "1", "#text": "first"},
+				map[string]any{"@id": "2", "name": "second", "tag": []any{"a", "b"}},
+			},
+			"empty": "",
+		},
+	}
+	is.Equal(cmp.Diff(want, got), "")
+}
+
+func TestParseXML_Invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := parseXML([]byte(`<envelope><item></envelope>`))
+	is.True(err != nil)
+
+	_, err = parseXML([]byte(`not xml`))
+	is.True(err != nil)
+}
+
+func TestSource_XMLResponse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, `<result count="1"><name>resource</name></result>`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"responseFormat": "xml",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(cmp.Diff(opencdc.StructuredData{
+		"result": map[string]any{"@count": "1", "name": "resource"},
+	}, rec.Payload.After), "")
+}
+
+func TestParseNDJSON(t *testing.T) {
+	is := is.New(t)
+
+	got, err := parseNDJSON([]byte("{\"id\": 1}\n\n{\"id\": 2, \"name\": \"b\"}\r\n\n"), false)
+	is.NoErr(err)
+	is.Equal(cmp.Diff([]opencdc.Data{
+		opencdc.StructuredData{"id": float64(1)},
+		opencdc.StructuredData{"id": float64(2), "name": "b"},
+	}, got), "")
+
+	_, err = parseNDJSON([]byte("{\"id\": 1}\n{\"id\": 2"), false)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "line 2"))
+}
+
+func TestSource_NDJSONResponse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{\"id\": \"a\"}\n{\"id\": \"b\"}\n")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"responseFormat":   "ndjson",
+		"response.keyPath": "id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"a", "b"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Payload.After, opencdc.StructuredData{"id": want})
+		is.Equal(rec.Key, opencdc.RawData(want))
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	is := is.New(t)
+
+	body := "id;name;note\r\n1;\"Doe; John\";\"line 1\nline 2\"\r\n2;Jane;\"say \"\"hi\"\"\"\r\n"
+	got, err := parseCSV([]byte(body), ';', true)
+	is.NoErr(err)
+	is.Equal(got, []opencdc.Data{
+		opencdc.StructuredData{"id": "1", "name": "Doe; John", "note": "line 1\nline 2"},
+		opencdc.StructuredData{"id": "2", "name": "Jane", "note": `say "hi"`},
+	})
+
+	got, err = parseCSV([]byte("a,b\nc,d\n"), ',', false)
+	is.NoErr(err)
+	is.Equal(got, []opencdc.Data{
+		opencdc.StructuredData{"0": "a", "1": "b"},
+		opencdc.StructuredData{"0": "c", "1": "d"},
+	})
+
+	_, err = parseCSV([]byte("id,name\n1\n"), ',', true)
+	is.True(err != nil)
+}
+
+func TestSourceConfig_CSVDelimiter(t *testing.T) {
+	is := is.New(t)
+
+	cfg := SourceConfig{CSVDelimiter: "\t"}
+	delimiter, err := cfg.csvDelimiter()
+	is.NoErr(err)
+	is.Equal(delimiter, '\t')
+
+	for _, invalid := range []string{"", ";;", `"`, "\n"} {
+		cfg.CSVDelimiter = invalid
+		_, err = cfg.csvDelimiter()
+		is.True(err != nil)
+	}
+}
+
+func TestSource_CSVResponse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "id,amount\na,10\nb,20\n")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"responseFormat":   "csv",
+		"response.keyPath": "id",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []opencdc.StructuredData{{"id": "a", "amount": "10"}, {"id": "b", "amount": "20"}} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Payload.After, want)
+		is.Equal(rec.Key, opencdc.RawData(want["id"].(string)))
+	}
+}
+
+func TestSource_GzipCSVDownload(t *testing.T) {
+	var report bytes.Buffer
+	zw := gzip.NewWriter(&report)
+	_, _ = zw.Write([]byte("id,amount\na,10\nb,20\n"))
+	_ = zw.Close()
+
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{{
+		name: "attachment",
+		handler: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/gzip")
+			w.Header().Set("Content-Disposition", `attachment; filename="report.csv.gz"`)
+			_, _ = w.Write(report.Bytes())
+		},
+	}, {
+		// the same body, compressed using the Content-Encoding
+		name: "content encoding",
+		handler: func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(report.Bytes())
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			server := httptest.NewServer(tc.handler)
+			t.Cleanup(server.Close)
+
+			src := NewSource()
+			err := src.Configure(ctx, map[string]string{
+				"url":            server.URL,
+				"responseFormat": "csv",
+				"compression":    "gzip",
+			})
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.NoErr(err)
+
+			for _, want := range []opencdc.StructuredData{{"id": "a", "amount": "10"}, {"id": "b", "amount": "20"}} {
+				rec, err := src.Read(ctx)
+				is.NoErr(err)
+				is.Equal(rec.Payload.After, want)
+			}
+		})
+	}
+}
+
+func TestSource_AcceptHeader(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[%q]}`, r.Header.Get("Accept"))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name string
+		cfg  map[string]string
+		want string
+	}{{
+		name: "json default",
+		cfg:  map[string]string{},
+		want: "application/json",
+	}, {
+		name: "configured",
+		cfg:  map[string]string{"acceptHeader": "application/vnd.api+json"},
+		want: "application/vnd.api+json",
+	}, {
+		name: "headers take precedence",
+		cfg:  map[string]string{"acceptHeader": "application/vnd.api+json", "headers": "Accept:text/plain"},
+		want: "text/plain",
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			tc.cfg["url"] = server.URL
+			tc.cfg["response.recordsPath"] = "items"
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.NoErr(err)
+
+			rec, err := src.Read(ctx)
+			is.NoErr(err)
+			is.Equal(string(rec.Payload.After.Bytes()), `"`+tc.want+`"`)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// maxRetryAfter is the longest delay before a retry, a longer delay requested
+// by the server using the Retry-After header is shortened.
+const maxRetryAfter = 5 * time.Minute
+
+// doWithRetry sends the request using the client and retries it if it fails
+// with a transient network error or with one of the configured retryable
+// status codes. The delay between attempts grows exponentially, unless the
+// server specifies it using the Retry-After header. The response or error of
+// the last attempt is returned. Network errors are only retried if the request
+// is idempotent, i.e. it can be processed twice by the server without side
+// effects.
+func (s *Config) doWithRetry(client *http.Client, req *http.Request, idempotent bool) (*http.Response, error) {
+	ctx := req.Context()
+	backoff := s.RetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error resetting request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		resp, err := client.Do(req)
+		err = s.redactError(err)
+		if attempt >= s.RetryMaxAttempts || ctx.Err() != nil || !s.isRetryable(resp, err, idempotent) {
+			return resp, err
+		}
+
+		delay := backoff
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+			}
+			// drain the body, so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		logEvent := sdk.Logger(ctx).Warn().
+			Int("attempt", attempt).
+			Dur("delay", delay)
+		if err != nil {
+			logEvent = logEvent.Err(err)
+		} else {
+			logEvent = logEvent.Int("status", resp.StatusCode)
+		}
+		logEvent.Msg("request failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		backoff = time.Duration(float64(backoff) * s.RetryBackoffFactor)
+	}
+}
+
+func (s *Config) isRetryable(resp *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		return idempotent && isTransientError(err)
+	}
+	return s.classifyStatus(resp.StatusCode, nil) == statusRetryable
+}
+
+// isIdempotent returns true if the request's method is idempotent, so that the
+// request can be sent again after a network error. A network error can happen
+// after the server processed the request, so requests with other methods, e.g.
+// POST, could be processed twice.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientError returns true if the error of a request is a network error
+// that may not happen again: a timeout, a refused or reset connection, or a
+// connection closed before the whole response was received. Other errors, e.g.
+// too many redirects or an invalid TLS certificate, would happen again and
+// aren't retried.
+func isTransientError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		// a connection closed by the server before sending a response
+		errors.Is(err, io.EOF)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date. The delay is at most maxRetryAfter.
+func parseRetryAfter(val string) (time.Duration, bool) {
+	if val == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(val); err == nil {
+		return min(max(time.Duration(seconds)*time.Second, 0), maxRetryAfter), true
+	}
+	if date, err := http.ParseTime(val); err == nil {
+		return min(max(time.Until(date), 0), maxRetryAfter), true
+	}
+	return 0, false
+}
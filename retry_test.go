@@ -0,0 +1,297 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestDestination_Retry(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":               server.URL,
+		"retry.maxAttempts": "3",
+		"retry.backoff":     "1ms",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Payload: opencdc.Change{After: opencdc.RawData("payload")},
+	}})
+	is.NoErr(err)
+	// the body is sent again on every attempt
+	is.Equal(bodies, []string{"payload", "payload", "payload"})
+}
+
+func TestDestination_RetryIdempotencyKey(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"retry.backoff":  "1ms",
+		"idempotencyKey": "Idempotency-Key",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{
+		Position: opencdc.Position("1"),
+		Key:      opencdc.RawData("a"),
+	}, {
+		Position: opencdc.Position("2"),
+		Key:      opencdc.RawData("a"),
+	}})
+	is.NoErr(err)
+	is.Equal(len(keys), 3)
+	// the retried request uses the same key
+	is.Equal(keys[0], keys[1])
+	is.True(keys[0] != "")
+	is.True(keys[1] != keys[2])
+}
+
+func TestDestination_RetryExhausted(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":               server.URL,
+		"retry.maxAttempts": "2",
+		"retry.backoff":     "1ms",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{}})
+	is.True(err != nil)
+	is.Equal(attempts, 2)
+}
+
+// closeConnection closes the connection of the request without sending a
+// response, which results in a network error in the client.
+func closeConnection(w http.ResponseWriter) {
+	conn, _, err := w.(http.Hijacker).Hijack()
+	if err == nil {
+		conn.Close()
+	}
+}
+
+func TestDestination_RetryNetworkErrorNotIdempotent(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		attempts++
+		closeConnection(w)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":               server.URL,
+		"method":            http.MethodPost,
+		"retry.maxAttempts": "3",
+		"retry.backoff":     "1ms",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{}})
+	is.True(err != nil)
+	// the server might have processed the POST request, so it isn't sent again
+	is.Equal(attempts, 1)
+}
+
+func TestDestination_RetryNetworkErrorIdempotencyKey(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			closeConnection(w)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"method":         http.MethodPost,
+		"retry.backoff":  "1ms",
+		"idempotencyKey": "Idempotency-Key",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{Position: opencdc.Position("1")}})
+	is.NoErr(err)
+	// the server discards the request if it processed it already
+	is.Equal(len(keys), 2)
+	is.Equal(keys[0], keys[1])
+}
+
+func TestSource_RetryNetworkError(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		attempts++
+		if attempts == 1 {
+			closeConnection(w)
+			return
+		}
+		_, _ = w.Write([]byte("data"))
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":               server.URL,
+		"retry.maxAttempts": "3",
+		"retry.backoff":     "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Payload.After.Bytes(), []byte("data"))
+	is.Equal(attempts, 2)
+}
+
+func TestIsTransientError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "timeout", err: &url.Error{Op: "Post", URL: "http://x", Err: &net.OpError{Op: "dial", Err: timeoutError{}}}, want: true},
+		{name: "connection refused", err: &url.Error{Op: "Post", URL: "http://x", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}, want: true},
+		{name: "connection reset", err: &url.Error{Op: "Post", URL: "http://x", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, want: true},
+		{name: "unexpected EOF", err: &url.Error{Op: "Post", URL: "http://x", Err: io.ErrUnexpectedEOF}, want: true},
+		{name: "redirects", err: &url.Error{Op: "Post", URL: "http://x", Err: errors.New("stopped after 10 redirects")}, want: false},
+		{name: "certificate", err: &url.Error{Op: "Post", URL: "http://x", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(isTransientError(tc.err), tc.want)
+		})
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestParseRetryAfter(t *testing.T) {
+	is := is.New(t)
+
+	got, ok := parseRetryAfter("2")
+	is.True(ok)
+	is.Equal(got, 2*time.Second)
+
+	got, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	is.True(ok)
+	is.Equal(got, time.Duration(0))
+
+	// a long delay is shortened
+	got, ok = parseRetryAfter("86400")
+	is.True(ok)
+	is.Equal(got, maxRetryAfter)
+
+	_, ok = parseRetryAfter("soon")
+	is.True(!ok)
+}
@@ -17,18 +17,41 @@ package http
 //go:generate paramgen -output=paramgen_src.go SourceConfig
 
 import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/conduitio/conduit-commons/config"
 	"github.com/conduitio/conduit-commons/opencdc"
 	sdk "github.com/conduitio/conduit-connector-sdk"
-	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	metadataStatusCode = "http.response.statusCode"
+	metadataStatus     = "http.response.status"
+	metadataRequestURL = "http.request.url"
+)
+
+var errResponseTooLarge = errors.New("response body exceeds maxResponseBytes")
+
+const (
+	sourceModePoll     = "poll"
+	sourceModeLongPoll = "longpoll"
+	sourceModeWebhook  = "webhook"
 )
 
 //go:generate mockgen -destination=mock_request_builder.go -source=source.go -package=http -mock_names=requestBuilder=MockRequestBuilder . requestBuilder
@@ -43,7 +66,16 @@ type requestBuilder interface {
 }
 
 type responseParser interface {
-	parse(ctx context.Context, responseBytes []byte) (*Response, error)
+	parse(ctx context.Context, responseBytes []byte, meta responseMetadata) (*Response, error)
+}
+
+// responseMetadata contains information about the HTTP response that is passed
+// to the response parser together with the response body.
+type responseMetadata struct {
+	StatusCode int
+	Header     http.Header
+	// Request is the request that produced the response.
+	Request *Request
 }
 
 type Source struct {
@@ -52,40 +84,371 @@ type Source struct {
 	config SourceConfig
 	header http.Header
 
-	client  *http.Client
-	limiter *rate.Limiter
+	client *http.Client
+	// scriptClient is used for the requests sent by scripts, it's the same as
+	// client unless OAuth2 is configured
+	scriptClient *http.Client
+	// pollingPeriod is the current polling period, which only changes if
+	// pollingPeriod.adaptive is enabled
+	pollingPeriod time.Duration
+	lastPoll      time.Time
+	// polledRecords is the number of records fetched since the last poll
+	polledRecords int
 
 	lastResponseData map[string]any
+	lastETag         string
+	lastModified     string
+	lastTimestamp    int64
+	lastHasMore      bool
+	fetchNextPage    bool
+	nextPageURL      string
+	offset           int
 	buffer           []opencdc.Record
 	lastPosition     opencdc.Position
 
 	requestBuilder requestBuilder
 	responseParser responseParser
+	// operations determines the operation of records parsed without a script
+	operations *operationMapper
+	// protoMessage is the descriptor of the response message with the protobuf format
+	protoMessage protoreflect.MessageDescriptor
+
+	webhook *webhookServer
+
+	// urls contains the configured url or urls, requests are sent to urls[urlIndex]
+	urls     []string
+	urlIndex int
+	// urlTmpls contains the parsed template of each url that is a template,
+	// and nil for static urls
+	urlTmpls []*template.Template
+	// graphQLVariablesTmpl is the parsed graphql.variables, if it's a template
+	graphQLVariablesTmpl *template.Template
+
+	expectedStatus statusRanges
 }
 
 type SourceConfig struct {
 	Config
-	// Http url to send requests to
-	URL string `json:"url" validate:"required"`
+	// Http url to send requests to, required unless urls is set or the mode is `webhook`.
+	// The url can be a Go template, evaluated before each request against the data of the
+	// previous response (e.g. `{{.cursor}}` with response.cursorPath) and the last
+	// `{{.position}}`, with the sprig functions, `pathEscape` and `queryEscape` available.
+	// Values that aren't set yet can be replaced using `default`, e.g.
+	// `?since={{.cursor | default "0" | queryEscape}}`.
+	URL string `json:"url"`
+	// Http urls of mirror endpoints to send requests to, used instead of url. Each poll is
+	// sent to the next url in the list, and if a poll fails, it's retried against the
+	// following urls before returning an error. A Request returned by getRequestData
+	// takes precedence.
+	URLs []string `json:"urls"`
 	// how often the connector will get data from the url
 	PollingPeriod time.Duration `json:"pollingPeriod" default:"5m"`
+	// Fraction by which each polling period is randomized, e.g. with `0.1` the time between
+	// two polls is a random value within ±10% of the polling period. Spreads out the requests
+	// of connectors polling the same API. Needs to be between 0 and 1.
+	PollingJitter float64 `json:"pollingJitter"`
+	// Whether the polling period adapts to the amount of data. The period starts at
+	// pollingPeriod, it's doubled after each poll that returns no records, up to
+	// pollingPeriod.max, and halved after each poll that returns records, down to
+	// pollingPeriod.min.
+	PollingPeriodAdaptive bool `json:"pollingPeriod.adaptive"`
+	// Minimum polling period used with pollingPeriod.adaptive.
+	PollingPeriodMin time.Duration `json:"pollingPeriod.min" default:"1s"`
+	// Maximum polling period used with pollingPeriod.adaptive.
+	PollingPeriodMax time.Duration `json:"pollingPeriod.max" default:"1h"`
+	// Whether a heartbeat is logged at info level after each poll that returns no records,
+	// to tell an idle source apart from a stuck one.
+	EmitHeartbeat bool `json:"emitHeartbeat" default:"false"`
+	// How data is fetched from the url. With `poll`, a request is sent every pollingPeriod.
+	// With `longpoll`, the server is expected to hold the request open until data is
+	// available, and a new request is sent right after each response, pollingPeriod is
+	// ignored. With `webhook`, the connector doesn't send requests, but starts an HTTP
+	// server and emits the body of each POST request it receives as a record. A request
+	// is answered with `200 OK` once its record is read, before the record is acked, so
+	// records can be lost if the connector stops in between (at-most-once delivery).
+	// Request bodies larger than 10 MiB are rejected with `413 Request Entity Too Large`.
+	Mode string `json:"mode" default:"poll" validate:"inclusion=poll|longpoll|webhook"`
+	// Maximum time a request is held open in the `longpoll` mode. A request that doesn't
+	// return within this time is considered to contain no data and is sent again.
+	LongPollMaxIdle time.Duration `json:"longPoll.maxIdle" default:"90s"`
+	// Address the HTTP server listens on in the `webhook` mode.
+	WebhookListenAddr string `json:"webhook.listenAddr" default:":8080"`
+	// Path the HTTP server accepts requests on in the `webhook` mode.
+	WebhookPath string `json:"webhook.path" default:"/"`
+	// Shared secret expected in the webhook.secretHeader header of incoming requests in
+	// the `webhook` mode, requests with a different value are rejected with 401 Unauthorized.
+	WebhookSecret string `json:"webhook.secret"`
+	// Name of the header containing the shared secret, used with webhook.secret.
+	WebhookSecretHeader string `json:"webhook.secretHeader" default:"X-Webhook-Secret"`
 	// Http method to use in the request
-	Method string `default:"GET" validate:"inclusion=GET|HEAD|OPTIONS"`
+	Method string `default:"GET" validate:"inclusion=GET|HEAD|OPTIONS|POST"`
+	// Body to send in the request, e.g. a JSON search query. The body is sent with any
+	// method, including GET. A body returned by getRequestData takes precedence.
+	RequestBody string `json:"requestBody"`
+	// Response status codes that are considered successful, as a list of codes or ranges,
+	// e.g. `200-299,304`. Responses with other status codes result in an error.
+	ExpectedStatus []string `json:"expectedStatus" default:"200-299"`
+	// Url of the request sent to check the connection when the connector starts, e.g. a
+	// health endpoint. When empty, the check is sent to every url data is fetched from.
+	ValidateConnectionURL string `json:"validateConnection.url"`
+	// Whether an OPTIONS request is sent to each url when the connector starts, to check
+	// that method is one of the methods listed in the `Allow` response header. The check
+	// passes if the server doesn't return an `Allow` header.
+	ValidateConnectionPreflight bool `json:"validateConnection.preflight" default:"false"`
+	// Whether the `ETag` of a response is sent in the `If-None-Match` header of the next
+	// poll, so that the server can reply with `304 Not Modified` if nothing changed.
+	// The ETag is stored in the record positions.
+	ConditionalRequests bool `json:"conditionalRequests" default:"false"`
+	// Where the time sent in the `If-Modified-Since` header of conditional requests comes
+	// from. With `header`, it's the `Last-Modified` header of the previous response. With
+	// `position`, it's the position of the last record, and with `metadata:<key>` (e.g.
+	// `metadata:updatedAt`) the metadata field of the last record. The value can be an HTTP
+	// date, an RFC 3339 timestamp or a Unix timestamp in seconds, as well as the position of
+	// a record without a position of its own (`unix-<nanoseconds>`). When empty, only the
+	// `ETag` is used. Requires conditionalRequests.
+	ConditionalRequestsLastModifiedFrom string `json:"conditionalRequests.lastModifiedFrom"`
+	// Compression of the file contained in the response body, e.g. a report downloaded as a
+	// `.csv.gz` attachment. With `gzip`, a body containing gzip data is decompressed before it's
+	// parsed according to responseFormat, otherwise it's used as is. This is independent of the
+	// `Content-Encoding` header, bodies compressed using the header are always decompressed.
+	Compression string `json:"compression" validate:"inclusion=gzip"`
+	// Position the source starts from when it's started without a position, e.g. a
+	// timestamp or a cursor. It's passed to getRequestData as the position and available
+	// as `{{.position}}` in url templates. With response.cursorPath, it's also used as the
+	// initial cursor (`previousResponse.cursor` and `{{.cursor}}`).
+	StartPosition string `json:"startPosition"`
+	// Maximum size of a response body in bytes, after decompression. A larger response
+	// results in an error, instead of being truncated. Only the records of one response are
+	// buffered at a time, the next request, including the one for a follow-up page, is sent
+	// once all of them are read, so this also limits the memory used by buffered records.
+	MaxResponseBytes int64 `json:"maxResponseBytes" default:"52428800" validate:"gt=0"`
+	// Format of the response body, used when no script.parseResponse is configured.
+	// With `raw`, the body is stored in the record as raw data. With `xml`, the body
+	// is decoded into structured data, where attributes are prefixed with `@` and
+	// the text of elements that have attributes or child elements is stored as `#text`.
+	// With `ndjson`, each non-empty line of the body is decoded as a JSON object into
+	// a separate record. With `csv`, each row is decoded into a separate record, see
+	// csv.delimiter and csv.hasHeader. With `protobuf`, the body is decoded as the protobuf message
+	// response.protobuf.message into structured data, using the protobuf JSON mapping
+	// with the field names of the .proto file.
+	ResponseFormat string `json:"responseFormat" default:"raw" validate:"inclusion=raw|xml|ndjson|csv|protobuf"`
+	// Path to a binary protobuf descriptor set containing the response message, used with
+	// responseFormat protobuf. It can be created using
+	// `protoc --include_imports --descriptor_set_out=feed.binpb feed.proto`.
+	ResponseProtobufDescriptorSet string `json:"response.protobuf.descriptorSet"`
+	// Full name of the protobuf message the response is decoded as, e.g. `feed.v1.Batch`,
+	// used with responseFormat protobuf.
+	ResponseProtobufMessage string `json:"response.protobuf.message"`
+	// Delimiter between the fields of a CSV response, used with responseFormat csv. Needs to
+	// be a single character, e.g. `;`.
+	CSVDelimiter string `json:"csv.delimiter" default:","`
+	// Whether the first row of a CSV response contains the column names, used with
+	// responseFormat csv. The names are used as the keys of the records, otherwise the keys
+	// are the indexes of the columns, starting at `0`.
+	CSVHasHeader bool `json:"csv.hasHeader" default:"true"`
+	// Value of the Accept header sent with requests, unless the header is set in headers.
+	// Defaults to `application/json` when the response is parsed as JSON (response.recordsPath
+	// or graphql.query), to `application/xml` with the `xml` format and to
+	// `application/x-ndjson, application/json` with the `ndjson` format and to
+	// `application/x-protobuf` with the `protobuf` format and to `text/csv` with the `csv` format,
+	// otherwise no Accept header is sent.
+	AcceptHeader string `json:"acceptHeader"`
+	// Response headers that are added to the record metadata, comma separated list. When
+	// empty, all headers are added. Setting it is recommended, as headers like `Set-Cookie`
+	// can contain sensitive data.
+	ResponseMetadataHeaders []string `json:"response.metadataHeaders"`
+	// Prefix added to the names of the response headers in the record metadata, e.g.
+	// `http.header.`, to keep them apart from other metadata fields.
+	ResponseHeaderPrefix string `json:"response.headerPrefix"`
+	// Path to the array of records in a JSON response, e.g. `data.items`. When set and
+	// no script.parseResponse is configured, each element of the array is emitted as a
+	// separate record.
+	ResponseRecordsPath string `json:"response.recordsPath"`
+	// Named arrays of records in a JSON response, used instead of response.recordsPath to
+	// extract records from multiple arrays, as a comma separated list of : separated pairs,
+	// e.g. `users:users,orders:data.orders`. The records of each array are emitted in the
+	// listed order, and the name of the array is added to their metadata as
+	// `http.response.recordType`.
+	ResponseRecordsPaths []string `json:"response.recordsPaths"`
+	// Path to the key within each record, e.g. `id`. With response.recordsPath, the key is
+	// taken from each element of the records array, otherwise from the decoded response
+	// (or from each line with the `ndjson` format, or each row with the `csv` format). When
+	// not set or not found, the time of the response is used as the key.
+	ResponseKeyPath string `json:"response.keyPath"`
+	// Paths to the keys of the records of the arrays in response.recordsPaths, as a comma
+	// separated list of : separated pairs of an array name and a path, e.g.
+	// `users:id,orders:order.id`. Arrays that aren't listed use response.keyPath.
+	ResponseKeyPaths []string `json:"response.keyPaths"`
+	// Path to a cursor in a JSON response, e.g. `meta.nextCursor`, used with
+	// response.recordsPath(s) or graphql.query. The cursor is passed to getRequestData as `previousResponse.cursor`.
+	// A number or boolean is passed as a string, a number with its exact value.
+	ResponseCursorPath string `json:"response.cursorPath"`
+	// Whether numbers in JSON responses are decoded with their exact value, used with
+	// response.recordsPath, graphql.query and the `ndjson` format. By default numbers are
+	// decoded as floating point numbers, which rounds integers larger than 2^53, e.g. the ID
+	// `1234567890123456789` becomes `1234567890123456800`. When enabled, numbers are kept as
+	// their JSON text (a `json.Number`, which is a string) and are encoded as the same number
+	// again. Responses parsed using script.parseResponse are always decoded by JavaScript and
+	// have the same limitation, in that case such values need to be strings in the response.
+	ResponseUseNumber bool `json:"response.useNumber" default:"false"`
+	// Operation of the records parsed without script.parseResponse, used when
+	// response.operationPath isn't set or its value isn't a known operation.
+	ResponseOperation string `json:"response.operation" default:"create" validate:"inclusion=create|update|delete|snapshot"`
+	// Path to a field in each record whose value determines the record's operation, e.g.
+	// `status`. The value is mapped using response.operationMapping, otherwise it's
+	// parsed as an operation name (create, update, delete or snapshot).
+	ResponseOperationPath string `json:"response.operationPath"`
+	// Mapping of values found at response.operationPath to operations, comma separated
+	// list of : separated pairs, e.g. `deleted:delete,modified:update`.
+	ResponseOperationMapping []string `json:"response.operationMapping"`
+	// Pagination mode used to fetch follow-up pages right away, without waiting for the
+	// next poll. With `link`, the URL of the next page is taken from the `Link` response
+	// header with the relation type `next`, until a response doesn't contain it.
+	// With `offset`, the offset and limit query parameters are added to the request and
+	// the offset is increased by the number of records in each page, until a page
+	// contains fewer than pagination.pageSize records. The offset is stored in the
+	// record positions, `offset` requires a response that contains multiple records.
+	PaginationMode string `json:"pagination.mode" validate:"inclusion=link|offset"`
+	// Name of the query parameter containing the page size, used with the `offset` pagination mode.
+	PaginationLimitParam string `json:"pagination.limitParam" default:"limit"`
+	// Name of the query parameter containing the offset, used with the `offset` pagination mode.
+	PaginationOffsetParam string `json:"pagination.offsetParam" default:"offset"`
+	// Number of records requested per page, used with the `offset` pagination mode.
+	PaginationPageSize int `json:"pagination.pageSize" default:"100" validate:"gt=0"`
+	// Maximum number of records fetched in one poll when following pagination. The limit
+	// is checked after each page, once it's reached the remaining pages are fetched in the
+	// next poll, starting from the next page. Zero means no limit.
+	MaxRecordsPerPoll int `json:"maxRecordsPerPoll" default:"0" validate:"gt=-1"`
+
+	// GraphQL query sent to the url. When set, the query is sent in a POST request, and
+	// the records are extracted from the response data using graphql.dataPath.
+	// Errors in the response are returned as connector errors.
+	GraphQLQuery string `json:"graphql.query"`
+	// Variables of the GraphQL query, as a JSON object. The variables can be a Go template,
+	// evaluated before each request with the same data and functions as url, e.g.
+	// `{"since": {{ .cursor | default "" | toJson }}}` to pass the cursor extracted using
+	// response.cursorPath.
+	GraphQLVariables string `json:"graphql.variables"`
+	// Path to the array of records within the data of a GraphQL response, e.g.
+	// `users.nodes`. Required with graphql.query. response.keyPath and response.cursorPath
+	// can be used to extract the key of each record and a cursor from the response.
+	GraphQLDataPath string `json:"graphql.dataPath"`
 
-	// The path to a .js file containing the code to prepare the request data.
+	// The path to a .js file containing the code to prepare the request data, or the code itself.
 	// The signature of the function needs to be:
 	// `function getRequestData(cfg, previousResponse, position)` where:
 	// * `cfg` (a map) is the connector configuration
 	// * `previousResponse` (a map) contains data from the previous response (if any), returned by `parseResponse`
 	// * `position` (a byte array) contains the starting position of the connector.
-	// The function needs to return a Request object.
+	// The function needs to return a Request object. Its `URL`, `Method` and `Body` are used
+	// for the request, and its `Headers` (a map) are added to the configured headers. An empty
+	// `Method` or `Body` falls back to the configured one.
 	GetRequestDataScript string `json:"script.getRequestData"`
-	// The path to a .js file containing the code to parse the response.
+	// The path to a .js file containing the code to parse the response, or the code itself.
 	// The signature of the function needs to be:
-	// `function parseResponse(bytes)` where
-	// `bytes` are the original response's raw bytes (i.e. unparsed).
-	// The response should be a Response object.
+	// `function parseResponse(bytes, response)` where
+	// * `bytes` are the original response's raw bytes (i.e. unparsed)
+	// * `response` (an object) contains the response's `statusCode`, `headers` (multiple values are comma separated)
+	// and the `request` that produced it, with its `url` and `body`.
+	// The response should be a Response object. Without pagination.mode, setting its `HasMore`
+	// to true requests the next page right away instead of waiting for the next poll.
 	ParseResponseScript string `json:"script.parseResponse"`
+	// Maximum time a call of script.getRequestData or script.parseResponse can take. A script
+	// that doesn't finish in time, e.g. because it's stuck in a loop, is aborted and the read
+	// fails. Zero means no timeout.
+	ScriptTimeout time.Duration `json:"script.timeout" default:"30s"`
+}
+
+func (s *SourceConfig) Validate() error {
+	err := s.Config.Validate()
+	if err != nil {
+		return err
+	}
+	if s.URL != "" && len(s.URLs) > 0 {
+		return errors.New("url and urls can't be used together")
+	}
+	if s.URL == "" && len(s.URLs) == 0 && s.Mode != sourceModeWebhook {
+		return errors.New("url or urls is required")
+	}
+	if s.Mode == sourceModeLongPoll && s.LongPollMaxIdle <= 0 {
+		return errors.New("longPoll.maxIdle needs to be greater than 0")
+	}
+	err = s.validatePolling()
+	if err != nil {
+		return err
+	}
+	err = s.validateResponse()
+	if err != nil {
+		return err
+	}
+	err = s.validateConditional()
+	if err != nil {
+		return err
+	}
+	err = s.validateScripts()
+	if err != nil {
+		return err
+	}
+	return s.validateGraphQL()
+}
+
+// validateScripts checks that the configured scripts can be read and compiled
+// and that they declare the expected functions, without running them.
+func (s *SourceConfig) validateScripts() error {
+	if s.GetRequestDataScript != "" {
+		err := validateScript(s.GetRequestDataScript, getRequestDataFn)
+		if err != nil {
+			return fmt.Errorf("invalid script.getRequestData: %w", err)
+		}
+	}
+	if s.ParseResponseScript != "" {
+		err := validateScript(s.ParseResponseScript, parseResponseFn)
+		if err != nil {
+			return fmt.Errorf("invalid script.parseResponse: %w", err)
+		}
+	}
+	return nil
+}
+
+// hasRecordsPath returns true if records are extracted from JSON responses
+// using response.recordsPath or response.recordsPaths.
+func (s *SourceConfig) hasRecordsPath() bool {
+	return s.ResponseRecordsPath != "" || len(s.ResponseRecordsPaths) > 0
+}
+
+// validateRecordsPaths validates the paths of the records in JSON responses.
+func (s *SourceConfig) validateRecordsPaths() error {
+	if s.ResponseRecordsPath != "" && len(s.ResponseRecordsPaths) > 0 {
+		return errors.New("response.recordsPath and response.recordsPaths can't be used together")
+	}
+	_, err := s.recordsArrays()
+	return err
+}
+
+// validateResponse validates the options for parsing the response.
+func (s *SourceConfig) validateResponse() error {
+	if err := s.validateRecordsPaths(); err != nil {
+		return err
+	}
+	if s.ResponseCursorPath != "" && !s.hasRecordsPath() && s.GraphQLQuery == "" {
+		return errors.New("response.cursorPath requires response.recordsPath(s) or graphql.query to be set")
+	}
+	if s.hasRecordsPath() && s.ResponseFormat != responseFormatRaw {
+		return fmt.Errorf("response.recordsPath(s) can't be used with responseFormat %v", s.ResponseFormat)
+	}
+	if s.ResponseFormat == responseFormatProtobuf && (s.ResponseProtobufDescriptorSet == "" || s.ResponseProtobufMessage == "") {
+		return errors.New("responseFormat protobuf requires response.protobuf.descriptorSet and response.protobuf.message to be set")
+	}
+	if s.ResponseFormat == responseFormatCSV {
+		if _, err := s.csvDelimiter(); err != nil {
+			return err
+		}
+	}
+	if s.PaginationMode == paginationModeOffset && s.ParseResponseScript == "" && !s.hasRecordsPath() &&
+		s.ResponseFormat != responseFormatNDJSON && s.ResponseFormat != responseFormatCSV {
+		return errors.New("pagination mode offset requires script.parseResponse or response.recordsPath to be set, or responseFormat to be ndjson or csv")
+	}
+	return nil
 }
 
 func NewSource() sdk.Source {
@@ -103,7 +466,15 @@ func (s *Source) Configure(ctx context.Context, cfg config.Config) error {
 	if err != nil {
 		return fmt.Errorf("invalid config: %w", err)
 	}
-	s.config.URL, err = s.config.addParamsToURL(s.config.URL)
+	err = s.config.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	s.expectedStatus, err = parseStatusRanges(s.config.ExpectedStatus)
+	if err != nil {
+		return fmt.Errorf("invalid expectedStatus: %w", err)
+	}
+	err = s.initURLs()
 	if err != nil {
 		return err
 	}
@@ -111,19 +482,54 @@ func (s *Source) Configure(ctx context.Context, cfg config.Config) error {
 	if err != nil {
 		return fmt.Errorf("invalid header config: %w", err)
 	}
+	if accept := s.config.acceptHeader(); accept != "" && s.header.Get("Accept") == "" {
+		s.header.Set("Accept", accept)
+	}
+	if s.config.GraphQLQuery != "" {
+		err = s.initGraphQL()
+		if err != nil {
+			return fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	return s.initParsers(ctx, cfg)
+}
+
+// initParsers creates the operation mapper, the request builder and the
+// response parser, based on the configured scripts and response options.
+func (s *Source) initParsers(ctx context.Context, cfg config.Config) error {
+	var err error
+	s.operations, err = newOperationMapper(s.config)
+	if err != nil {
+		return err
+	}
+
+	if s.config.ResponseFormat == responseFormatProtobuf {
+		s.protoMessage, err = loadProtoMessage(s.config.ResponseProtobufDescriptorSet, s.config.ResponseProtobufMessage)
+		if err != nil {
+			return err
+		}
+	}
 
 	if s.config.GetRequestDataScript != "" {
-		s.requestBuilder, err = newJSRequestBuilder(ctx, cfg, s.config.GetRequestDataScript)
+		s.requestBuilder, err = newJSRequestBuilder(ctx, cfg, s.config.GetRequestDataScript, s.config.ScriptTimeout)
 		if err != nil {
 			return fmt.Errorf("failed initializing %v: %w", getRequestDataFn, err)
 		}
 	}
 
 	if s.config.ParseResponseScript != "" {
-		s.responseParser, err = newJSResponseParser(ctx, s.config.ParseResponseScript)
+		s.responseParser, err = newJSResponseParser(ctx, s.config.ParseResponseScript, s.config.ScriptTimeout)
 		if err != nil {
 			return fmt.Errorf("failed initializing %v: %w", parseResponseFn, err)
 		}
+	} else if s.config.hasRecordsPath() {
+		s.responseParser, err = newJSONResponseParser(s.config, s.operations)
+	} else if s.config.GraphQLQuery != "" {
+		s.responseParser, err = newGraphQLResponseParser(s.config, s.operations)
+	}
+	if err != nil {
+		return err
 	}
 
 	return nil
@@ -131,41 +537,131 @@ func (s *Source) Configure(ctx context.Context, cfg config.Config) error {
 
 func (s *Source) Open(ctx context.Context, pos opencdc.Position) error {
 	sdk.Logger(ctx).Info().Msg("opening source")
+	var err error
+	if s.config.Mode == sourceModeWebhook {
+		s.webhook, err = s.startWebhookServer(ctx)
+		return err
+	}
+
 	// create client
-	s.client = &http.Client{}
+	s.client, err = s.config.newClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed creating HTTP client: %w", err)
+	}
+	s.scriptClient, err = s.config.newScriptClient(ctx, s.client)
+	if err != nil {
+		return fmt.Errorf("failed creating HTTP client for scripts: %w", err)
+	}
+	if rb, ok := s.requestBuilder.(*jsRequestBuilder); ok {
+		rb.gojaCtx.client = s.scriptClient
+		rb.gojaCtx.maxBodyBytes = s.config.MaxResponseBytes
+		rb.gojaCtx.cfg = &s.config.Config
+	}
+	if rp, ok := s.responseParser.(*jsResponseParser); ok {
+		rp.gojaCtx.client = s.scriptClient
+		rp.gojaCtx.maxBodyBytes = s.config.MaxResponseBytes
+		rp.gojaCtx.cfg = &s.config.Config
+	}
+
+	s.pollingPeriod = s.config.PollingPeriod
+	// the position is restored first, the connection test uses the URL of the
+	// next request
+	s.restorePosition(ctx, pos)
 
 	// check connection
-	err := s.testConnection(ctx)
-	if err != nil {
-		return fmt.Errorf("failed connection test: %w", err)
+	if s.config.ValidateConnection {
+		err = s.testConnection(ctx)
+		if err != nil {
+			return fmt.Errorf("failed connection test: %w", err)
+		}
 	}
 
-	s.limiter = rate.NewLimiter(rate.Every(s.config.PollingPeriod), 1)
-	s.lastPosition = pos
+	if s.config.ValidateConnectionPreflight {
+		err = s.preflight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed preflight check: %w", err)
+		}
+	}
 
 	return nil
 }
 
 func (s *Source) testConnection(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.config.URL, nil)
+	if s.config.ValidateConnectionURL != "" {
+		return s.testURL(ctx, s.config.ValidateConnectionURL)
+	}
+	for i := range s.urls {
+		u, err := s.evaluateURL(i)
+		if err != nil {
+			return err
+		}
+		err = s.testURL(ctx, u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Source) testURL(ctx context.Context, u string) error {
+	req, err := http.NewRequestWithContext(ctx, s.config.ValidateConnectionMethod, u, nil)
 	if err != nil {
-		return fmt.Errorf("error creating HTTP request %q: %w", s.config.URL, err)
+		return fmt.Errorf("error creating HTTP request %q: %w", s.config.redactURL(u), s.config.redactError(err))
 	}
 	req.Header = s.header
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("error pinging URL %q: %w", s.config.URL, err)
+		return fmt.Errorf("error pinging URL %q: %w", s.config.redactURL(u), s.config.redactError(err))
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode >= 300 {
+	if !s.expectedStatus.contains(resp.StatusCode) {
 		return fmt.Errorf("invalid response status code: (%d) %s", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
-	s.limiter = rate.NewLimiter(rate.Every(s.config.PollingPeriod), 1)
 
 	return nil
 }
 
+// preflight sends an OPTIONS request to each url and checks that the
+// configured method is allowed, based on the Allow response header.
+func (s *Source) preflight(ctx context.Context) error {
+	for i := range s.urls {
+		u, err := s.evaluateURL(i)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodOptions, u, nil)
+		if err != nil {
+			return fmt.Errorf("error creating HTTP request %q: %w", s.config.redactURL(u), s.config.redactError(err))
+		}
+		req.Header = s.header
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("error sending OPTIONS request to %q: %w", s.config.redactURL(u), s.config.redactError(err))
+		}
+		resp.Body.Close()
+
+		allow := resp.Header.Values("Allow")
+		if len(allow) == 0 {
+			sdk.Logger(ctx).Warn().
+				Str("url", s.config.redactURL(u)).
+				Msg("the OPTIONS response doesn't contain an Allow header, skipping the method check")
+			continue
+		}
+		allowed := slices.ContainsFunc(strings.Split(strings.Join(allow, ","), ","), func(m string) bool {
+			return strings.EqualFold(strings.TrimSpace(m), s.config.Method)
+		})
+		if !allowed {
+			return fmt.Errorf("method %v isn't allowed by %q, the allowed methods are %v", s.config.Method, s.config.redactURL(u), strings.Join(allow, ","))
+		}
+	}
+	return nil
+}
+
 func (s *Source) Read(ctx context.Context) (opencdc.Record, error) {
+	if s.webhook != nil {
+		return s.webhook.read(ctx)
+	}
+
 	rec, err := s.getRecord(ctx)
 	if err != nil {
 		return opencdc.Record{}, fmt.Errorf("error getting data: %w", err)
@@ -175,15 +671,28 @@ func (s *Source) Read(ctx context.Context) (opencdc.Record, error) {
 
 func (s *Source) getRecord(ctx context.Context) (opencdc.Record, error) {
 	if len(s.buffer) == 0 {
-		err := s.limiter.Wait(ctx)
-		if err != nil {
-			return opencdc.Record{}, err
+		// follow-up pages and long-polls are fetched right away, unless
+		// maxRecordsPerPoll is reached
+		poll := (!s.hasNextPage() || s.pollLimitReached()) && s.config.Mode != sourceModeLongPoll
+		if poll {
+			err := s.waitForPoll(ctx)
+			if err != nil {
+				return opencdc.Record{}, err
+			}
+			s.polledRecords = 0
 		}
 
-		err = s.fillBuffer(ctx)
+		err := s.fillBuffer(ctx)
 		if err != nil {
 			return opencdc.Record{}, err
 		}
+		s.polledRecords += len(s.buffer)
+		if poll {
+			s.adaptPollingPeriod(ctx, len(s.buffer))
+		}
+		if len(s.buffer) == 0 {
+			s.heartbeat(ctx)
+		}
 	}
 
 	if len(s.buffer) == 0 {
@@ -194,7 +703,7 @@ func (s *Source) getRecord(ctx context.Context) (opencdc.Record, error) {
 
 	rec := s.buffer[0]
 	s.buffer = s.buffer[1:]
-	s.lastPosition = rec.Position
+	s.lastPosition = s.unwrapPosition(rec.Position)
 
 	return rec, nil
 }
@@ -204,87 +713,271 @@ func (s *Source) Ack(ctx context.Context, position opencdc.Position) error {
 	return nil
 }
 
-func (s *Source) Teardown(context.Context) error {
+func (s *Source) Teardown(ctx context.Context) error {
 	if s.client != nil {
+		releaseRateLimit(s.client.Transport)
 		s.client.CloseIdleConnections()
 	}
+	if s.scriptClient != nil {
+		releaseRateLimit(s.scriptClient.Transport)
+		s.scriptClient.CloseIdleConnections()
+	}
+	if s.webhook != nil {
+		err := s.webhook.shutdown(ctx)
+		if err != nil {
+			return fmt.Errorf("failed shutting down webhook server: %w", err)
+		}
+	}
 
 	return nil
 }
 
+// fillBuffer sends a request to the next url and adds the records from the
+// response to the buffer. If the request fails, the following urls are tried,
+// until one of them succeeds.
 func (s *Source) fillBuffer(ctx context.Context) error {
+	start := len(s.buffer)
+	var err error
+	for range s.urls {
+		err = s.fillBufferFromURL(ctx)
+		failedURL := s.urls[s.urlIndex]
+		s.urlIndex = (s.urlIndex + 1) % len(s.urls)
+		if err == nil || ctx.Err() != nil || len(s.urls) == 1 {
+			return err
+		}
+		// drop the records of a partially parsed response
+		s.buffer = s.buffer[:start]
+		sdk.Logger(ctx).Warn().
+			Err(err).
+			Str("url", s.config.redactURL(failedURL)).
+			Msg("request failed, trying the next url")
+	}
+	return err
+}
+
+func (s *Source) fillBufferFromURL(ctx context.Context) error {
 	sdk.Logger(ctx).Debug().Msg("filling buffer")
 	// create request
 	reqData, err := s.getRequestData(ctx)
 	if err != nil {
 		return err
 	}
+	firstPage := !s.hasNextPage()
+	err = s.applyPagination(reqData)
+	if err != nil {
+		return err
+	}
 
-	sdk.Logger(ctx).Debug().Msg("request URL: " + reqData.URL)
-	req, err := http.NewRequestWithContext(ctx, s.config.Method, reqData.URL, nil)
+	sdk.Logger(ctx).Debug().Msg("request URL: " + s.config.redactURL(reqData.URL))
+	var body io.Reader
+	if reqData.Body != "" {
+		body = strings.NewReader(reqData.Body)
+	}
+	client, reqCtx := s.client, ctx
+	if s.config.Mode == sourceModeLongPoll {
+		// the server holds a long-poll open until data is available, so the
+		// request is bounded by longPoll.maxIdle instead of requestTimeout
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, s.config.LongPollMaxIdle)
+		defer cancel()
+		longPollClient := *s.client
+		longPollClient.Timeout = 0
+		client = &longPollClient
+	}
+	req, err := http.NewRequestWithContext(reqCtx, reqData.Method, reqData.URL, body)
 	if err != nil {
 		return fmt.Errorf("error creating HTTP request: %w", err)
 	}
-	req.Header = s.header
+	req.Header = s.requestHeader(reqData, firstPage)
 
 	// get response
-	resp, err := s.client.Do(req)
+	sent := time.Now()
+	resp, err := s.config.doWithRetry(client, req, isIdempotent(req))
 	if err != nil {
+		if ctx.Err() == nil && errors.Is(reqCtx.Err(), context.DeadlineExceeded) {
+			sdk.Logger(ctx).Debug().Msg("long-poll request reached longPoll.maxIdle without data")
+			return nil
+		}
 		return fmt.Errorf("error getting data from URL: %w", err)
 	}
 	defer resp.Body.Close()
+	// includes retries, but not reading the body
+	sdk.Logger(ctx).Debug().
+		Int("status", resp.StatusCode).
+		Dur("duration", time.Since(sent)).
+		Msg("request completed")
 
-	// NB: Conduit's built-in HTTP processor parses responses in the same way
-	if resp.StatusCode >= 300 {
+	if resp.StatusCode == http.StatusNotModified {
+		// nothing changed since the previous response, the buffer stays empty
+		// and the same request is sent again on the next poll
+		sdk.Logger(ctx).Debug().Msg("resource not modified")
+		s.fetchNextPage = false
+		return nil
+	}
+	if !s.expectedStatus.contains(resp.StatusCode) {
 		return s.buildError(resp)
 	}
 
-	err = s.parseResponse(ctx, resp)
+	start := len(s.buffer)
+	prevState := s.state()
+	err = s.parseResponse(ctx, reqData, resp)
 	if err != nil {
 		return fmt.Errorf("failed parsing response: %w", err)
 	}
+	records := s.buffer[start:]
+	s.updateValidators(resp, records, firstPage)
+
+	err = s.wrapPositions(records, prevState)
+	if err != nil {
+		return err
+	}
+	s.updatePagination(resp, records)
 
 	return nil
 }
 
+// readBody reads the body up to maxResponseBytes. If the body is larger,
+// errResponseTooLarge is returned together with the truncated body.
+func (s *Source) readBody(body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, s.config.MaxResponseBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.config.MaxResponseBytes {
+		return data[:s.config.MaxResponseBytes], fmt.Errorf("%w (%d bytes)", errResponseTooLarge, s.config.MaxResponseBytes)
+	}
+	return data, nil
+}
+
 func (s *Source) buildError(resp *http.Response) error {
 	errorMsg := "unknown"
-	body, err := io.ReadAll(resp.Body)
-	if err == nil {
+	body, err := s.readBody(resp.Body)
+	switch {
+	case err == nil:
 		errorMsg = string(body)
+	case errors.Is(err, errResponseTooLarge):
+		errorMsg = string(body) + "... (truncated)"
 	}
 
-	return fmt.Errorf(
-		"expected response status 200, but got status=%v, cause=%v",
-		resp.StatusCode,
-		errorMsg,
-	)
+	return s.config.newStatusError(resp, s.expectedStatus, s.config.redactSecrets(errorMsg))
+}
+
+// initURLs sets up the urls requests are sent to. The params are added to
+// static urls right away, and to url templates after they are evaluated.
+func (s *Source) initURLs() error {
+	s.urls = s.config.URLs
+	if s.config.URL != "" {
+		s.urls = []string{s.config.URL}
+	}
+	s.urlTmpls = make([]*template.Template, len(s.urls))
+	var err error
+	for i, u := range s.urls {
+		if strings.Contains(u, "{{") {
+			s.urlTmpls[i], err = template.New("").Funcs(templateFuncs()).Parse(u)
+			if err != nil {
+				return fmt.Errorf("error while parsing the URL template: %w", err)
+			}
+			continue
+		}
+		s.urls[i], err = s.config.addParamsToURL(u)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateData returns the data templates are evaluated against, which is the
+// data of the previous response and the last position.
+func (s *Source) templateData() map[string]any {
+	data := map[string]any{"position": string(s.lastPosition)}
+	maps.Copy(data, s.lastResponseData)
+	return data
+}
+
+// evaluateURL returns the url at index i. A url template is evaluated
+// against the data of the previous response and the last position.
+func (s *Source) evaluateURL(i int) (string, error) {
+	tmpl := s.urlTmpls[i]
+	if tmpl == nil {
+		return s.urls[i], nil
+	}
+	var b strings.Builder
+	err := tmpl.Execute(&b, s.templateData())
+	if err != nil {
+		return "", fmt.Errorf("error while evaluating URL template: %w", err)
+	}
+	return s.config.addParamsToURL(b.String())
 }
 
 func (s *Source) getRequestData(ctx context.Context) (*Request, error) {
 	if s.requestBuilder == nil {
-		return &Request{URL: s.config.URL}, nil
+		u, err := s.evaluateURL(s.urlIndex)
+		if err != nil {
+			return nil, err
+		}
+		body, err := s.requestBody()
+		if err != nil {
+			return nil, err
+		}
+		return &Request{URL: u, Method: s.config.Method, Body: body}, nil
 	}
 
-	return s.requestBuilder.build(ctx, s.lastResponseData, s.lastPosition)
+	reqData, err := s.requestBuilder.build(ctx, s.lastResponseData, s.lastPosition)
+	if err != nil {
+		return nil, err
+	}
+	if reqData.Body == "" {
+		reqData.Body, err = s.requestBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch reqData.Method {
+	case "":
+		reqData.Method = s.config.Method
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions:
+	default:
+		return nil, fmt.Errorf("getRequestData returned an unsupported method %q", reqData.Method)
+	}
+	return reqData, nil
 }
 
-func (s *Source) parseResponse(ctx context.Context, resp *http.Response) error {
+func (s *Source) parseResponse(ctx context.Context, reqData *Request, resp *http.Response) error {
 	sdk.Logger(ctx).Debug().Msg("parsing response")
 
+	bodyReader, err := decompressBody(resp)
+	if err == nil {
+		bodyReader, err = s.decompressFile(bodyReader)
+	}
+	if err != nil {
+		return fmt.Errorf("error decompressing body for response %v: %w", resp, err)
+	}
+
 	// read body
-	body, err := io.ReadAll(resp.Body)
+	body, err := s.readBody(bodyReader)
 	if err != nil {
 		return fmt.Errorf("error reading body for response %v: %w", resp, err)
 	}
 
-	// no custom parsing, the whole response is transformed into a record
+	// no custom parsing, the response is transformed into records based on its format
 	if s.responseParser == nil {
-		s.buffer = append(s.buffer, s.parseAsSingleRecord(resp, body))
+		payloads, err := s.decodeBody(body)
+		if err != nil {
+			return err
+		}
+		for _, payload := range payloads {
+			s.buffer = append(s.buffer, s.parseAsSingleRecord(resp, payload))
+		}
 		return nil
 	}
 
-	respData, err := s.responseParser.parse(ctx, body)
+	respData, err := s.responseParser.parse(ctx, body, responseMetadata{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Request:    reqData,
+	})
 	if err != nil {
 		return err
 	}
@@ -299,10 +992,68 @@ func (s *Source) parseResponse(ctx context.Context, resp *http.Response) error {
 		s.buffer = append(s.buffer, rec)
 	}
 	s.lastResponseData = respData.CustomData
+	s.lastHasMore = respData.HasMore
 
 	return nil
 }
 
+// decompressBody returns a reader that decompresses the response body based on
+// its Content-Encoding. Go's transport decompresses responses transparently
+// only if it requested compression itself, which isn't the case when the
+// Accept-Encoding header is configured, or when the server compresses
+// responses regardless of the request.
+func decompressBody(resp *http.Response) (io.Reader, error) {
+	var body io.Reader
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = zr
+	case "deflate":
+		// "deflate" should be zlib wrapped, but some servers send raw deflate data
+		br := bufio.NewReader(resp.Body)
+		header, _ := br.Peek(2)
+		if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			zr, err := zlib.NewReader(br)
+			if err != nil {
+				return nil, err
+			}
+			body = zr
+		} else {
+			body = flate.NewReader(br)
+		}
+	default:
+		return resp.Body, nil
+	}
+
+	// the same as Go's transport, remove the headers describing the compressed body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return body, nil
+}
+
+// decompressFile returns a reader that decompresses a body containing a file
+// compressed using the configured compression, regardless of the headers. The
+// body is only decompressed if it starts with the gzip magic number, so that
+// a body that was compressed using only the Content-Encoding (and has already
+// been decompressed) is used as is.
+func (s *Source) decompressFile(body io.Reader) (io.Reader, error) {
+	if s.config.Compression != compressionGzip {
+		return body, nil
+	}
+	br := bufio.NewReader(body)
+	magic, _ := br.Peek(2)
+	if !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return br, nil
+	}
+	return gzip.NewReader(br)
+}
+
 func (s *Source) toSDKRecord(jsRec *jsRecord, resp *http.Response) (opencdc.Record, error) {
 	toSDKData := func(d interface{}) opencdc.Data {
 		switch v := d.(type) {
@@ -320,7 +1071,7 @@ func (s *Source) toSDKRecord(jsRec *jsRecord, resp *http.Response) (opencdc.Reco
 		return opencdc.Record{}, fmt.Errorf("could not unmarshal operation: %w", err)
 	}
 
-	meta := s.headersToMetadata(resp.Header)
+	meta := s.responseToMetadata(resp)
 	maps.Copy(meta, jsRec.Metadata)
 
 	return opencdc.Record{
@@ -335,24 +1086,87 @@ func (s *Source) toSDKRecord(jsRec *jsRecord, resp *http.Response) (opencdc.Reco
 	}, nil
 }
 
-func (s *Source) parseAsSingleRecord(resp *http.Response, body []byte) opencdc.Record {
-	now := time.Now().Unix()
+// decodeBody decodes the response body according to the configured response
+// format, into the payloads of the records that are produced.
+func (s *Source) decodeBody(body []byte) ([]opencdc.Data, error) {
+	switch s.config.ResponseFormat {
+	case responseFormatXML:
+		sd, err := parseXML(body)
+		if err != nil {
+			return nil, err
+		}
+		return []opencdc.Data{sd}, nil
+	case responseFormatNDJSON:
+		return parseNDJSON(body, s.config.ResponseUseNumber)
+	case responseFormatCSV:
+		// the delimiter is checked when validating the config
+		delimiter, _ := s.config.csvDelimiter()
+		return parseCSV(body, delimiter, s.config.CSVHasHeader)
+	case responseFormatProtobuf:
+		sd, err := parseProtobuf(body, s.protoMessage)
+		if err != nil {
+			return nil, err
+		}
+		return []opencdc.Data{sd}, nil
+	default:
+		return []opencdc.Data{opencdc.RawData(body)}, nil
+	}
+}
+
+func (s *Source) parseAsSingleRecord(resp *http.Response, payload opencdc.Data) opencdc.Record {
+	now := s.nextTimestamp()
+	key := opencdc.Data(opencdc.RawData(fmt.Sprintf("%v", now)))
+	if s.config.ResponseKeyPath != "" {
+		if k, ok := lookupPayloadPath(payload, s.config.ResponseKeyPath); ok {
+			key = opencdc.RawData(formatValue(k))
+		}
+	}
 	return opencdc.Record{
 		Payload: opencdc.Change{
 			Before: nil,
-			After:  opencdc.RawData(body),
+			After:  payload,
 		},
-		Metadata:  s.headersToMetadata(resp.Header),
-		Operation: opencdc.OperationCreate,
+		Metadata:  s.responseToMetadata(resp),
+		Operation: s.operations.forPayload(payload),
 		Position:  opencdc.Position(fmt.Sprintf("unix-%v", now)),
-		Key:       opencdc.RawData(fmt.Sprintf("%v", now)),
+		Key:       key,
 	}
 }
 
+// nextTimestamp returns the current time in nanoseconds, used as the position
+// of records that don't have a position of their own. The returned timestamps
+// are strictly increasing, even if the records are created at the same time or
+// the clock goes backwards, so that each record gets a unique position.
+func (s *Source) nextTimestamp() int64 {
+	s.lastTimestamp = max(time.Now().UnixNano(), s.lastTimestamp+1)
+	return s.lastTimestamp
+}
+
+// responseToMetadata returns the metadata of a record parsed from the response,
+// containing the response headers and status, and the redacted URL of the
+// request that produced it.
+func (s *Source) responseToMetadata(resp *http.Response) opencdc.Metadata {
+	meta := s.headersToMetadata(resp.Header)
+	meta[metadataStatusCode] = strconv.Itoa(resp.StatusCode)
+	meta[metadataStatus] = http.StatusText(resp.StatusCode)
+	if resp.Request != nil && resp.Request.URL != nil {
+		meta[metadataRequestURL] = s.config.redactURL(resp.Request.URL.Redacted())
+	}
+	return meta
+}
+
 func (s *Source) headersToMetadata(header http.Header) opencdc.Metadata {
 	meta := opencdc.Metadata{}
-	for key, val := range header {
-		meta[key] = strings.Join(val, ",")
+	if len(s.config.ResponseMetadataHeaders) > 0 {
+		for _, key := range s.config.ResponseMetadataHeaders {
+			if val := header.Values(key); len(val) > 0 {
+				meta[s.config.ResponseHeaderPrefix+http.CanonicalHeaderKey(key)] = strings.Join(val, ",")
+			}
+		}
+	} else {
+		for key, val := range header {
+			meta[s.config.ResponseHeaderPrefix+key] = strings.Join(val, ",")
+		}
 	}
 	meta.SetReadAt(time.Now())
 
@@ -15,9 +15,19 @@
 package http
 
 import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -78,9 +88,15 @@ func createServer(t *testing.T) {
 		WriteTimeout: 10 * time.Second,
 	}
 
+	// Listen before returning, so the server is reachable once the function returns
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("failed to listen on %v: %v", address, err)
+	}
+
 	// Start the HTTP server
 	go func() {
-		err := serverInstance.ListenAndServe()
+		err := serverInstance.Serve(listener)
 		if err != nil {
 			fmt.Printf("Server error: %s\n", err)
 		}
@@ -120,6 +136,8 @@ func TestSource_Get(t *testing.T) {
 	rec, err := src.Read(ctx)
 	is.NoErr(err)
 	is.True(string(rec.Payload.After.Bytes()) == "This is resource 1")
+	is.Equal(rec.Metadata["http.response.statusCode"], "200")
+	is.Equal(rec.Metadata["http.response.status"], "OK")
 }
 
 func TestSource_Options(t *testing.T) {
@@ -144,6 +162,32 @@ func TestSource_Options(t *testing.T) {
 	is.Equal(meta, "GET, HEAD, OPTIONS")
 }
 
+func TestSource_MetadataHeaders(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	src := Source{}
+	createServer(t)
+
+	err := src.Configure(ctx, map[string]string{
+		"url":                      "http://localhost:8082/resource/resource1",
+		"method":                   "OPTIONS",
+		"response.metadataHeaders": "allow,X-Missing",
+		"response.headerPrefix":    "http.header.",
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Metadata["http.header.Allow"], "GET, HEAD, OPTIONS")
+	_, ok := rec.Metadata["http.header.Date"]
+	is.True(!ok)
+	_, ok = rec.Metadata["http.header.X-Missing"]
+	is.True(!ok)
+}
+
 func TestSource_Head(t *testing.T) {
 	is := is.New(t)
 	ctx := context.Background()
@@ -242,7 +286,7 @@ func TestSource_ParseResponse(t *testing.T) {
 
 	rp := NewMockResponseParser(gomock.NewController(t))
 	rp.EXPECT().
-		parse(ctx, []byte("This is resource 1")).
+		parse(ctx, []byte("This is resource 1"), gomock.Any()).
 		Return(
 			&Response{Records: []*jsRecord{{
 				Position:  []byte("pagination-token"),
@@ -273,9 +317,807 @@ func TestSource_ParseResponse(t *testing.T) {
 	want.Metadata["Content-Type"] = got.Metadata["Content-Type"]
 	want.Metadata["Date"] = got.Metadata["Date"]
 	want.Metadata["opencdc.readAt"] = got.Metadata["opencdc.readAt"]
+	want.Metadata["http.response.statusCode"] = "200"
+	want.Metadata["http.response.status"] = "OK"
+	want.Metadata["http.request.url"] = "http://localhost:8082/resource/resource1"
+	// the position also contains the state of the source
+	want.Position, err = json.Marshal(sourcePosition{Position: want.Position})
+	is.NoErr(err)
 
 	diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(opencdc.Record{}))
 	if diff != "" {
 		t.Errorf("mismatch (-want +got): %s", diff)
 	}
 }
+
+func TestSource_PostWithBody(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "results for %s", body)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":         server.URL + "/_search",
+		"method":      "POST",
+		"requestBody": `{"query":{"match_all":{}}}`,
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), `results for {"query":{"match_all":{}}}`)
+}
+
+func TestSource_RestoreResponseData(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageToken") == "page-2" {
+			fmt.Fprint(w, `{"some_objects": [{"id": "c"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"nextPageToken": "page-2", "some_objects": [{"id": "a"}, {"id": "b"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := map[string]string{
+		"url":                   server.URL,
+		"script.getRequestData": "./test/get_request_data.js",
+		"script.parseResponse":  "./test/parse_response.js",
+	}
+
+	src := NewSource()
+	err := src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	var last opencdc.Record
+	for _, want := range []string{"a", "b"} {
+		last, err = src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(last.Key.Bytes()), want)
+	}
+	is.NoErr(src.Teardown(ctx))
+
+	// the next page token is restored from the position
+	src = NewSource()
+	err = src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, last.Position)
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Key.Bytes()), "c")
+}
+
+func TestSource_CompressedResponse(t *testing.T) {
+	testCases := []struct {
+		encoding string
+		compress func(io.Writer) io.WriteCloser
+	}{{
+		encoding: "gzip",
+		compress: func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+	}, {
+		encoding: "deflate",
+		compress: func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+	}, {
+		encoding: "deflate",
+		compress: func(w io.Writer) io.WriteCloser {
+			fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return fw
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.encoding, func(t *testing.T) {
+			is := is.New(t)
+			ctx := context.Background()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", tc.encoding)
+				cw := tc.compress(w)
+				fmt.Fprint(cw, "compressed resource")
+				cw.Close()
+			}))
+			t.Cleanup(server.Close)
+
+			src := NewSource()
+			err := src.Configure(ctx, map[string]string{
+				"url": server.URL,
+				// prevents Go's transport from decompressing the response
+				"headers": "Accept-Encoding:" + tc.encoding,
+			})
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.NoErr(err)
+
+			rec, err := src.Read(ctx)
+			is.NoErr(err)
+			is.Equal(string(rec.Payload.After.Bytes()), "compressed resource")
+			_, ok := rec.Metadata["Content-Encoding"]
+			is.True(!ok)
+		})
+	}
+}
+
+func TestSource_MaxResponseBytes(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+		fmt.Fprint(w, "0123456789")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":              server.URL,
+		"maxResponseBytes": "5",
+		"pollingPeriod":    "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, errResponseTooLarge))
+
+	// error responses are truncated
+	status.Store(http.StatusInternalServerError)
+	_, err = src.Read(ctx)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "cause=01234... (truncated)"))
+}
+
+func TestSource_BufferOneResponse(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		requests.Add(1)
+		if r.URL.Query().Get("pageToken") == "page-2" {
+			fmt.Fprint(w, `{"some_objects": [{"id": "c"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"nextPageToken": "page-2", "some_objects": [{"id": "a"}, {"id": "b"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	src := &Source{}
+	err := src.Configure(ctx, map[string]string{
+		"url":                   server.URL,
+		"script.getRequestData": "./test/get_request_data.js",
+		"script.parseResponse":  "./test/parse_response.js",
+		"pollingPeriod":         "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	// the next page is only requested once the buffered records are read
+	for _, tc := range []struct {
+		key      string
+		buffered int
+		requests int32
+	}{
+		{key: "a", buffered: 1, requests: 1},
+		{key: "b", buffered: 0, requests: 1},
+		{key: "c", buffered: 0, requests: 2},
+	} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(string(rec.Key.Bytes()), tc.key)
+		is.Equal(len(src.buffer), tc.buffered)
+		is.Equal(requests.Load(), tc.requests)
+	}
+}
+
+func TestSource_UniquePositions(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"responseFormat": "ndjson",
+		"pollingPeriod":  "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	var positions []string
+	for range 6 {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		positions = append(positions, string(rec.Position))
+	}
+	for i := 1; i < len(positions); i++ {
+		is.True(positions[i-1] < positions[i]) // positions are strictly increasing
+	}
+}
+
+func TestSource_NextTimestamp(t *testing.T) {
+	is := is.New(t)
+
+	// the clock went backwards
+	future := time.Now().Add(time.Hour).UnixNano()
+	src := &Source{lastTimestamp: future}
+	is.Equal(src.nextTimestamp(), future+1)
+	is.Equal(src.nextTimestamp(), future+2)
+}
+
+func TestSource_GetWithBody(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "results for %s", body)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":         server.URL + "/_search",
+		"requestBody": `{"query":{"match_all":{}}}`,
+	})
+	is.NoErr(err)
+
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), `results for {"query":{"match_all":{}}}`)
+}
+
+func TestSource_URLTemplate(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since := r.URL.Query().Get("since")
+		fmt.Fprintf(w, `{"items":[{"since":%q,"id":%q}],"next":"%s+1"}`, since, r.URL.Query().Get("id"), since)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                  server.URL + `?since={{ .cursor | default "start" | urlquery }}`,
+		"params.id":            "1",
+		"response.recordsPath": "items",
+		"response.cursorPath":  "next",
+		"pollingPeriod":        "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for _, want := range []string{"start", "start+1", "start+1+1"} {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		is.Equal(rec.Payload.After.(opencdc.StructuredData)["since"], want)
+		is.Equal(rec.Payload.After.(opencdc.StructuredData)["id"], "1")
+	}
+
+	err = NewSource().Configure(ctx, map[string]string{"url": server.URL + "?since={{ .cursor"})
+	is.True(err != nil)
+}
+
+func TestSource_URLTemplateConnectionTest(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var tested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			tested = append(tested, r.URL.Query().Get("since"))
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"id":1}],"next":"abc"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := map[string]string{
+		"url":                  server.URL + `?since={{ .cursor | default "start" }}`,
+		"response.recordsPath": "items",
+		"response.cursorPath":  "next",
+	}
+	src := NewSource()
+	err := src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.NoErr(src.Teardown(ctx))
+
+	// the connection test uses the cursor restored from the position
+	src = NewSource()
+	err = src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, rec.Position)
+	is.NoErr(err)
+	is.Equal(tested, []string{"start", "abc"})
+}
+
+func TestSource_URLTemplateNumericCursor(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var since []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		since = append(since, r.URL.Query().Get("since"))
+		fmt.Fprintf(w, `{"items":[{"id":%d}],"next":%d}`, len(since), 1700000000+len(since))
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                  server.URL + `?since={{.cursor | default "0"}}`,
+		"response.recordsPath": "items",
+		"response.cursorPath":  "next",
+		"pollingPeriod":        "1ms",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	for range 3 {
+		_, err := src.Read(ctx)
+		is.NoErr(err)
+	}
+	is.Equal(since, []string{"0", "1700000001", "1700000002"})
+}
+
+func TestSource_URLs(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	newServer := func(name string, healthy *atomic.Bool) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !healthy.Load() && r.Method != http.MethodHead {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprint(w, name+"?"+r.URL.RawQuery)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	var healthy1, healthy2 atomic.Bool
+	healthy1.Store(true)
+	healthy2.Store(true)
+	url1, url2 := newServer("mirror-1", &healthy1), newServer("mirror-2", &healthy2)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"urls":              url1 + "," + url2,
+		"params.id":         "1",
+		"pollingPeriod":     "1ms",
+		"retry.maxAttempts": "1",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	read := func() string {
+		rec, err := src.Read(ctx)
+		is.NoErr(err)
+		return string(rec.Payload.After.Bytes())
+	}
+
+	// polls are spread across the urls
+	is.Equal(read(), "mirror-1?id=1")
+	is.Equal(read(), "mirror-2?id=1")
+	is.Equal(read(), "mirror-1?id=1")
+
+	// a failed poll is retried against the next url
+	healthy2.Store(false)
+	is.Equal(read(), "mirror-1?id=1")
+
+	healthy1.Store(false)
+	_, err = src.Read(ctx)
+	is.True(err != nil)
+}
+
+func TestSource_Preflight(t *testing.T) {
+	ctx := context.Background()
+
+	newServer := func(allow string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions && allow != "" {
+				w.Header().Set("Allow", allow)
+			}
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	testCases := []struct {
+		name    string
+		allow   string
+		wantErr bool
+	}{
+		{name: "allowed", allow: "OPTIONS, GET, HEAD"},
+		{name: "not allowed", allow: "OPTIONS, POST", wantErr: true},
+		{name: "no Allow header", allow: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			src := NewSource()
+			err := src.Configure(ctx, map[string]string{
+				"url":                          newServer(tc.allow),
+				"validateConnection.preflight": "true",
+			})
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			is.Equal(err != nil, tc.wantErr)
+		})
+	}
+}
+
+func TestSource_ValidateConnection(t *testing.T) {
+	ctx := context.Background()
+
+	var checks []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/health":
+			checks = append(checks, r.Method+" "+r.URL.Path)
+		case r.Method == http.MethodHead:
+			// the data path doesn't support HEAD
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.Method == http.MethodOptions:
+			checks = append(checks, r.Method+" "+r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name       string
+		cfg        map[string]string
+		wantChecks []string
+		wantErr    bool
+	}{{
+		name:    "default",
+		cfg:     map[string]string{},
+		wantErr: true,
+	}, {
+		name:       "method",
+		cfg:        map[string]string{"validateConnection.method": "OPTIONS"},
+		wantChecks: []string{"OPTIONS /data"},
+	}, {
+		name: "url",
+		cfg: map[string]string{
+			"validateConnection.method": "GET",
+			"validateConnection.url":    server.URL + "/health",
+		},
+		wantChecks: []string{"GET /health"},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			checks = nil
+
+			tc.cfg["url"] = server.URL + "/data"
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, opencdc.Position{})
+			if tc.wantErr {
+				is.True(err != nil)
+				return
+			}
+			is.NoErr(err)
+			is.Equal(checks, tc.wantChecks)
+		})
+	}
+}
+
+func TestSource_RequestDataHeaders(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%v|%v|%v", r.Header.Get("X-Signature"), r.Header.Get("Accept"), r.Header.Get("X-Static"))
+	}))
+	t.Cleanup(server.Close)
+
+	src := &Source{}
+	err := src.Configure(ctx, map[string]string{
+		"url":                   server.URL,
+		"headers":               "Accept:application/json,X-Static:static",
+		"script.getRequestData": "./test/get_request_data_headers.js",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	// headers returned by the script replace the configured ones
+	is.Equal(string(rec.Payload.After.Bytes()), "signed:"+server.URL+"|application/xml|static")
+	is.Equal(src.header.Get("Accept"), "application/json")
+}
+
+func TestSource_RequestURLMetadata(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("data"))
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                    server.URL + "/items/{{ .position | default \"start\" }}",
+		"params.page":            "1",
+		"auth.apiKey.queryParam": "key",
+		"auth.apiKey.value":      "secret",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(rec.Metadata["http.request.url"], server.URL+"/items/start?key=***&page=1")
+}
+
+func TestSource_StartPosition(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name string
+		cfg  map[string]string
+		pos  opencdc.Position
+		want string
+	}{{
+		name: "position",
+		cfg:  map[string]string{"url": "/items?since={{.position}}"},
+		want: "2024-01-01",
+	}, {
+		name: "cursor",
+		cfg: map[string]string{
+			"url":                  "/items?since={{.cursor}}",
+			"response.recordsPath": "items",
+			"response.cursorPath":  "next",
+		},
+		want: "2024-01-01",
+	}, {
+		name: "restart",
+		cfg:  map[string]string{"url": "/items?since={{.position}}"},
+		pos:  opencdc.Position("unix-1"),
+		want: "unix-1",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var since string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				since = r.URL.Query().Get("since")
+				fmt.Fprint(w, `{"items": [{"id": 1}], "next": "c1"}`)
+			}))
+			t.Cleanup(server.Close)
+
+			tc.cfg["url"] = server.URL + tc.cfg["url"]
+			tc.cfg["startPosition"] = "2024-01-01"
+			src := NewSource()
+			err := src.Configure(ctx, tc.cfg)
+			is.NoErr(err)
+			err = src.Open(ctx, tc.pos)
+			is.NoErr(err)
+
+			_, err = src.Read(ctx)
+			is.NoErr(err)
+			is.Equal(since, tc.want)
+		})
+	}
+}
+
+func TestSource_RequestDataMethod(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Method+" "+r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+
+	src := Source{}
+	err := src.Configure(ctx, map[string]string{
+		"url":           server.URL,
+		"pollingPeriod": "1ms",
+	})
+	is.NoErr(err)
+
+	rb := NewMockRequestBuilder(gomock.NewController(t))
+	gomock.InOrder(
+		rb.EXPECT().build(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&Request{URL: server.URL + "/search", Method: http.MethodPost}, nil),
+		rb.EXPECT().build(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&Request{URL: server.URL + "/fetch"}, nil),
+		rb.EXPECT().build(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&Request{URL: server.URL + "/fetch", Method: "FETCH"}, nil),
+	)
+	src.requestBuilder = rb
+
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "POST /search")
+
+	// an empty method falls back to the configured one
+	rec, err = src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "GET /fetch")
+
+	_, err = src.Read(ctx)
+	is.True(err != nil)
+}
+
+func TestSource_ScriptHTTPRequest(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || string(body) != `{"client":"conduit"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"token": "abc"}`)
+		case "/ping":
+			w.Header().Set("X-Pong", r.Header.Get("X-Ping"))
+		default:
+			fmt.Fprintf(w, "%v|%v", r.Header.Get("Authorization"), r.Header.Get("X-Ping"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                   server.URL,
+		"script.getRequestData": "./test/get_request_data_http.js",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "Bearer abc|1")
+}
+
+func TestSource_ScriptHTTPRequestTooLarge(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			fmt.Fprint(w, `{"token": "abcdefghijklmnopqrstuvwxyz"}`)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{
+		"url":                   server.URL,
+		"script.getRequestData": "./test/get_request_data_http.js",
+		"maxResponseBytes":      "32",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "response body exceeds maxResponseBytes (32 bytes)"))
+}
+
+func TestSource_ScriptHTTPRequestOAuth2(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"access_token":"oauth-token","token_type":"bearer","expires_in":3600}`)
+		case "/script":
+			fmt.Fprint(w, r.Header.Get("Authorization"))
+			fmt.Fprint(w, "0123456789")
+		default:
+			fmt.Fprintf(w, "%v|%v", r.Header.Get("Authorization"), r.Header.Get("X-Script"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	script := `function getRequestData(cfg, previousResponse, position) {
+		let resp = httpGet(cfg["url"] + "/script?token=secret-token")
+		let request = new Request()
+		request.URL = cfg["url"] + "/data"
+		request.Headers["X-Script"] = resp.body
+		return request
+	}`
+	cfg := map[string]string{
+		"url":                      server.URL,
+		"script.getRequestData":    script,
+		"auth.oauth2.tokenURL":     server.URL + "/token",
+		"auth.oauth2.clientID":     "client-id",
+		"auth.oauth2.clientSecret": "client-secret",
+	}
+
+	src := NewSource()
+	err := src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	// the token is only sent with the connector's requests
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "Bearer oauth-token|0123456789")
+	is.NoErr(src.Teardown(ctx))
+
+	// the URLs in errors are redacted
+	cfg["maxResponseBytes"] = "5"
+	src = NewSource()
+	err = src.Configure(ctx, cfg)
+	is.NoErr(err)
+	err = src.Open(ctx, nil)
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "/script?token=***"))
+	is.True(!strings.Contains(err.Error(), "secret-token"))
+}
@@ -0,0 +1,142 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// statusRange is an inclusive range of HTTP status codes.
+type statusRange struct {
+	from, to int
+}
+
+type statusRanges []statusRange
+
+// parseStatusRanges parses status codes (e.g. `204`) and ranges of status
+// codes (e.g. `200-299`).
+func parseStatusRanges(vals []string) (statusRanges, error) {
+	ranges := make(statusRanges, 0, len(vals))
+	for _, val := range vals {
+		val = strings.TrimSpace(val)
+		fromStr, toStr, isRange := strings.Cut(val, "-")
+		if !isRange {
+			toStr = fromStr
+		}
+
+		from, err := parseStatusCode(fromStr)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseStatusCode(toStr)
+		if err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, fmt.Errorf("invalid status range %q", val)
+		}
+		ranges = append(ranges, statusRange{from: from, to: to})
+	}
+	return ranges, nil
+}
+
+func parseStatusCode(val string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("invalid status code %q", val)
+	}
+	return code, nil
+}
+
+// statusCategory classifies a response status, so that callers can decide
+// how to handle it.
+type statusCategory int
+
+const (
+	// statusSuccess is an expected status.
+	statusSuccess statusCategory = iota
+	// statusRetryable is a status caused by a transient failure (one of
+	// retry.statusCodes), so the request can be retried.
+	statusRetryable
+	// statusFatal is a status for which retrying the request won't help,
+	// e.g. 400 Bad Request or 404 Not Found.
+	statusFatal
+)
+
+func (c statusCategory) String() string {
+	switch c {
+	case statusSuccess:
+		return "success"
+	case statusRetryable:
+		return "retryable"
+	case statusFatal:
+		return "fatal"
+	default:
+		return fmt.Sprintf("statusCategory(%d)", int(c))
+	}
+}
+
+// classifyStatus maps a status code to a category. The expected statuses are
+// successful, the configured retry.statusCodes (by default 429 and the
+// transient 5xx statuses) are retryable and all other statuses are fatal.
+func (s *Config) classifyStatus(code int, expected statusRanges) statusCategory {
+	switch {
+	case expected.contains(code):
+		return statusSuccess
+	case slices.Contains(s.RetryStatusCodes, code):
+		return statusRetryable
+	default:
+		return statusFatal
+	}
+}
+
+// statusError is returned when the server responds with a status that isn't
+// expected, i.e. when it rejects a request.
+type statusError struct {
+	status   string
+	category statusCategory
+	// cause is the (truncated) response body, if any.
+	cause string
+}
+
+func (e *statusError) Error() string {
+	if e.cause == "" {
+		return fmt.Sprintf("got an unexpected response status of %q (%v)", e.status, e.category)
+	}
+	return fmt.Sprintf("got an unexpected response status of %q (%v), cause=%v", e.status, e.category, e.cause)
+}
+
+// newStatusError creates the error for a response with an unexpected status.
+func (s *Config) newStatusError(resp *http.Response, expected statusRanges, cause string) *statusError {
+	return &statusError{
+		status:   resp.Status,
+		category: s.classifyStatus(resp.StatusCode, expected),
+		cause:    cause,
+	}
+}
+
+// contains returns true if the code is in any of the ranges.
+func (r statusRanges) contains(code int) bool {
+	for _, sr := range r {
+		if code >= sr.from && code <= sr.to {
+			return true
+		}
+	}
+	return false
+}
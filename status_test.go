@@ -0,0 +1,160 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/matryer/is"
+)
+
+func TestParseStatusRanges(t *testing.T) {
+	is := is.New(t)
+
+	ranges, err := parseStatusRanges([]string{"200-299", " 404"})
+	is.NoErr(err)
+	is.Equal(ranges, statusRanges{{from: 200, to: 299}, {from: 404, to: 404}})
+	is.True(ranges.contains(200))
+	is.True(ranges.contains(299))
+	is.True(ranges.contains(404))
+	is.True(!ranges.contains(300))
+
+	for _, invalid := range []string{"", "abc", "299-200", "200-", "99", "600"} {
+		_, err = parseStatusRanges([]string{invalid})
+		is.True(err != nil)
+	}
+}
+
+func TestConfig_ClassifyStatus(t *testing.T) {
+	is := is.New(t)
+
+	cfg := Config{RetryStatusCodes: []int{429, 502, 503, 504}}
+	expected := statusRanges{{from: 200, to: 299}}
+
+	testCases := map[int]statusCategory{
+		http.StatusOK:                  statusSuccess,
+		http.StatusNoContent:           statusSuccess,
+		http.StatusTooManyRequests:     statusRetryable,
+		http.StatusServiceUnavailable:  statusRetryable,
+		http.StatusBadRequest:          statusFatal,
+		http.StatusNotFound:            statusFatal,
+		http.StatusInternalServerError: statusFatal,
+	}
+	for code, want := range testCases {
+		is.Equal(cfg.classifyStatus(code, expected), want) // status code
+	}
+
+	// retry.statusCodes overrides the classification
+	cfg.RetryStatusCodes = []int{500}
+	is.Equal(cfg.classifyStatus(http.StatusInternalServerError, expected), statusRetryable)
+	is.Equal(cfg.classifyStatus(http.StatusTooManyRequests, expected), statusFatal)
+}
+
+func TestSource_ExpectedStatus(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "not found")
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{"url": server.URL})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+	_, err = src.Read(ctx)
+	var statusErr *statusError
+	is.True(errors.As(err, &statusErr))
+	is.Equal(statusErr.category, statusFatal)
+	is.Equal(statusErr.cause, "not found")
+
+	src = NewSource()
+	err = src.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"expectedStatus": "200-299,404",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(string(rec.Payload.After.Bytes()), "not found")
+}
+
+func TestDestination_ExpectedStatus(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	dest := NewDestination()
+	err := dest.Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"expectedStatus": "200,201",
+	})
+	is.NoErr(err)
+	err = dest.Open(ctx)
+	is.NoErr(err)
+
+	_, err = dest.Write(ctx, []opencdc.Record{{}})
+	is.True(err != nil)
+
+	err = NewDestination().Configure(ctx, map[string]string{
+		"url":            server.URL,
+		"expectedStatus": "2xx",
+	})
+	is.True(err != nil)
+}
+
+func TestSource_NotModified(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	t.Cleanup(server.Close)
+
+	src := NewSource()
+	err := src.Configure(ctx, map[string]string{"url": server.URL})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+
+	_, err = src.Read(ctx)
+	is.True(errors.Is(err, sdk.ErrBackoffRetry))
+}
@@ -0,0 +1,7 @@
+function getRequestData(cfg, previousResponse, position) {
+    let request = new Request()
+    request.URL = cfg["url"]
+    request.Body = JSON.stringify({query: {match_all: {}}, size: 2})
+
+    return request
+}
@@ -0,0 +1,8 @@
+function getRequestData(cfg, previousResponse, position) {
+    let request = new Request()
+    request.URL = cfg["url"]
+    request.Headers["X-Signature"] = "signed:" + cfg["url"]
+    request.Headers["Accept"] = "application/xml"
+
+    return request
+}
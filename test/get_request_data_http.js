@@ -0,0 +1,18 @@
+function getRequestData(cfg, previousResponse, position) {
+    let tokenResp = httpRequest({
+        url: cfg["url"] + "/token",
+        method: "POST",
+        headers: {"Content-Type": "application/json"},
+        body: JSON.stringify({client: "conduit"})
+    })
+    if (tokenResp.status !== 200) {
+        throw new Error("token request failed with status " + tokenResp.status)
+    }
+    let ping = httpGet(cfg["url"] + "/ping", {"X-Ping": "1"})
+
+    let request = new Request()
+    request.URL = cfg["url"] + "/data"
+    request.Headers["Authorization"] = "Bearer " + JSON.parse(tokenResp.body).token
+    request.Headers["X-Ping"] = ping.headers["X-Pong"]
+    return request
+}
@@ -0,0 +1,11 @@
+function getRequestData(cfg, previousResponse, position) {
+    let body = JSON.stringify({id: 1})
+
+    let request = new Request()
+    request.URL = cfg["url"]
+    request.Body = body
+    request.Headers["X-Content-SHA256"] = sha256(body)
+    request.Headers["X-Signature"] = hmacSHA256(base64Decode(cfg["secret"]), body)
+    request.Headers["X-Client"] = base64Encode("conduit")
+    return request
+}
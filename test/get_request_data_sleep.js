@@ -0,0 +1,8 @@
+function getRequestData(cfg, previousResponse, position) {
+    // pace the requests
+    sleep(parseInt(cfg["sleep"]))
+
+    let request = new Request()
+    request.URL = cfg["url"]
+    return request
+}
@@ -0,0 +1,19 @@
+function parseResponse(bytes, response) {
+    var str = String.fromCharCode.apply(String, bytes);
+    var items = JSON.parse(str);
+
+    const records = [];
+    for (const item of items) {
+        let rec = new Record()
+        rec.Position = item.id
+        rec.Key = new RawData(item.id)
+        rec.Payload.After = new StructuredData()
+        rec.Payload.After["name"] = item.name
+        records.push(rec)
+    }
+
+    var resp = new Response()
+    resp.Records = records
+
+    return resp
+}
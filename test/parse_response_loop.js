@@ -0,0 +1,4 @@
+function parseResponse(bytes, response) {
+    // never returns
+    while (true) {}
+}
@@ -0,0 +1,10 @@
+function parseResponse(bytes, response) {
+    var resp = new Response()
+    resp.CustomData["statusCode"] = response.statusCode
+    resp.CustomData["link"] = response.headers["Link"]
+    if (response.request) {
+        resp.CustomData["requestURL"] = response.request.url
+    }
+
+    return resp
+}
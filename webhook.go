@@ -0,0 +1,155 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// webhookMaxBodyBytes is the maximum size of a request body received by the
+// webhook server, larger requests are rejected.
+const webhookMaxBodyBytes = 10 << 20
+
+// webhookServer receives records pushed to the source by POST requests and
+// hands them over to Read.
+type webhookServer struct {
+	server  *http.Server
+	records chan opencdc.Record
+	// closed when the server is shutting down
+	done     chan struct{}
+	doneOnce sync.Once
+
+	// maxBodyBytes is the maximum size of a request body
+	maxBodyBytes int64
+	secretHeader string
+	secret       string
+	toMetadata   func(http.Header) opencdc.Metadata
+}
+
+// startWebhookServer starts listening on webhook.listenAddr, the returned
+// server needs to be stopped using shutdown.
+func (s *Source) startWebhookServer(ctx context.Context) (*webhookServer, error) {
+	listener, err := net.Listen("tcp", s.config.WebhookListenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed listening on %q: %w", s.config.WebhookListenAddr, err)
+	}
+
+	wh := &webhookServer{
+		records:      make(chan opencdc.Record),
+		done:         make(chan struct{}),
+		maxBodyBytes: webhookMaxBodyBytes,
+		secretHeader: s.config.WebhookSecretHeader,
+		secret:       s.config.WebhookSecret,
+		toMetadata:   s.headersToMetadata,
+	}
+	mux := http.NewServeMux()
+	mux.Handle(s.config.WebhookPath, wh)
+	wh.server = &http.Server{
+		Addr:              listener.Addr().String(),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger := sdk.Logger(ctx)
+	go func() {
+		err := wh.server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Err(err).Msg("webhook server stopped")
+		}
+	}()
+	logger.Info().
+		Str("addr", listener.Addr().String()).
+		Str("path", s.config.WebhookPath).
+		Msg("webhook server started")
+
+	return wh, nil
+}
+
+func (wh *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if wh.secret != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get(wh.secretHeader)), []byte(wh.secret)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, wh.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now().UnixNano()
+	rec := opencdc.Record{
+		Payload: opencdc.Change{
+			After: opencdc.RawData(body),
+		},
+		Metadata:  wh.toMetadata(r.Header),
+		Operation: opencdc.OperationCreate,
+		Position:  opencdc.Position(fmt.Sprintf("webhook-%v", now)),
+		Key:       opencdc.RawData(fmt.Sprintf("%v", now)),
+	}
+
+	// the response is sent once the record is read, so that senders are
+	// slowed down if the pipeline can't keep up. It's not delayed until the
+	// record is acked, so a record is lost if the connector stops before
+	// that (at-most-once delivery).
+	select {
+	case wh.records <- rec:
+		w.WriteHeader(http.StatusOK)
+	case <-wh.done:
+		w.WriteHeader(http.StatusServiceUnavailable)
+	case <-r.Context().Done():
+	}
+}
+
+// read returns the next record received by the server, it blocks until a
+// record is available or ctx is done.
+func (wh *webhookServer) read(ctx context.Context) (opencdc.Record, error) {
+	select {
+	case rec := <-wh.records:
+		return rec, nil
+	case <-ctx.Done():
+		return opencdc.Record{}, ctx.Err()
+	}
+}
+
+// shutdown gracefully stops the server, requests that are waiting for their
+// record to be read are answered with 503 Service Unavailable. It can be
+// called more than once.
+func (wh *webhookServer) shutdown(ctx context.Context) error {
+	wh.doneOnce.Do(func() { close(wh.done) })
+	return wh.server.Shutdown(ctx)
+}
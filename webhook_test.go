@@ -0,0 +1,91 @@
+// Copyright © 2024 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestSource_Webhook(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	src := &Source{}
+	err := src.Configure(ctx, map[string]string{
+		"mode":               "webhook",
+		"webhook.listenAddr": "127.0.0.1:0",
+		"webhook.path":       "/events",
+		"webhook.secret":     "s3cr3t",
+	})
+	is.NoErr(err)
+	err = src.Open(ctx, opencdc.Position{})
+	is.NoErr(err)
+	url := "http://" + src.webhook.server.Addr + "/events"
+	src.webhook.maxBodyBytes = 10
+
+	send := func(method, secret, body string) int {
+		req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+		is.NoErr(err)
+		req.Header.Set("X-Webhook-Secret", secret)
+		req.Header.Set("X-Event", "created")
+		resp, err := http.DefaultClient.Do(req)
+		is.NoErr(err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	is.Equal(send(http.MethodGet, "s3cr3t", ""), http.StatusMethodNotAllowed)
+	is.Equal(send(http.MethodPost, "wrong", "event-0"), http.StatusUnauthorized)
+	is.Equal(send(http.MethodPost, "s3cr3t", "a body that is too large"), http.StatusRequestEntityTooLarge)
+
+	status := make(chan int)
+	go func() {
+		status <- send(http.MethodPost, "s3cr3t", "event-1")
+	}()
+	rec, err := src.Read(ctx)
+	is.NoErr(err)
+	is.Equal(<-status, http.StatusOK)
+	is.Equal(rec.Operation, opencdc.OperationCreate)
+	is.Equal(string(rec.Payload.After.Bytes()), "event-1")
+	is.Equal(rec.Metadata["X-Event"], "created")
+
+	err = src.Teardown(ctx)
+	is.NoErr(err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader("event-2"))
+	is.NoErr(err)
+	_, err = http.DefaultClient.Do(req) //nolint:bodyclose // the server is closed
+	is.True(err != nil)
+
+	// stopping the source again doesn't panic
+	err = src.Teardown(ctx)
+	is.NoErr(err)
+}
+
+func TestSourceConfig_URLRequired(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	err := NewSource().Configure(ctx, map[string]string{})
+	is.True(err != nil)
+
+	err = NewSource().Configure(ctx, map[string]string{"mode": "webhook"})
+	is.NoErr(err)
+}